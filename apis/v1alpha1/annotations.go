@@ -0,0 +1,93 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package v1alpha1
+
+// AnnotationPrefix is the prefix of the annotations configuring how the
+// controller manages DynamoDB resources.
+const AnnotationPrefix = "dynamodb.services.k8s.aws/"
+
+const (
+	// ObserveOnlyAnnotation is the annotation that, when set to "true" on a
+	// Table, makes the controller only read the table. The controller never
+	// creates, updates, tags or deletes the table, and reports the
+	// differences between the desired and observed state in Status.Drift.
+	ObserveOnlyAnnotation = AnnotationPrefix + "observe-only"
+	// PlanModeAnnotation is the annotation configuring how the controller
+	// applies changes to a Table. When set to "dry-run", the controller only
+	// computes the operations it would make and reports them in Status.Plan.
+	// When set to "approve", the operations are only made once the plan is
+	// approved with the ApprovedPlanAnnotation.
+	PlanModeAnnotation = AnnotationPrefix + "plan-mode"
+	// ApprovedPlanAnnotation is the annotation approving the plan whose hash
+	// is reported in Status.Plan.Hash.
+	ApprovedPlanAnnotation = AnnotationPrefix + "approved-plan"
+	// MaintenanceWindowAnnotation is the annotation setting, on a namespace,
+	// the default maintenance window of the tables of the namespace. Its value
+	// is a JSON encoded MaintenanceWindow, e.g.
+	// {"schedule": "0 2 * * SAT", "duration": "4h", "timeZone": "Europe/Paris"}
+	MaintenanceWindowAnnotation = AnnotationPrefix + "maintenance-window"
+	// AttributeDefinitionsAnnotation is the annotation that, when set to
+	// "auto" on a Table, makes the controller derive the attribute
+	// definitions sent to DynamoDB from the key schemas of the table and of
+	// its indexes. Spec.AttributeDefinitions then only declares the type of
+	// each attribute, the definitions of unused attributes are not sent.
+	AttributeDefinitionsAnnotation = AnnotationPrefix + "attribute-definitions"
+	// TagOwnershipAnnotation is the annotation that, when set to "managed" on
+	// a Table, makes the controller only add, update and remove the tags it
+	// applied, leaving the tags added by other tools alone.
+	TagOwnershipAnnotation = AnnotationPrefix + "tag-ownership"
+	// ReplicaDeletionPolicyAnnotation is the annotation configuring how the
+	// controller deletes a Table that has replicas. When set to "delete", the
+	// replicas are deleted before the table. When set to "refuse", the table
+	// is not deleted while it has replicas. By default, the replicas are only
+	// deleted when the DeleteReplica action isn't a destructive operation.
+	ReplicaDeletionPolicyAnnotation = AnnotationPrefix + "replica-deletion-policy"
+	// IgnoreDependentsAnnotation is the annotation that, when set to "true"
+	// on a Table, makes the controller delete the table even when Backups or
+	// GlobalTables of its namespace reference it.
+	IgnoreDependentsAnnotation = AnnotationPrefix + "ignore-dependents"
+	// DataCopyWriteLossAcknowledgedAnnotation is the annotation that must be
+	// set to "true" on a Table replaced with the ScanAndWrite data copy. The
+	// copy scans the old table while applications may still write to it, the
+	// items written during the copy can be lost.
+	DataCopyWriteLossAcknowledgedAnnotation = AnnotationPrefix + "acknowledge-data-copy-write-loss"
+)
+
+const (
+	// PlanModeDryRun is the plan mode in which planned operations are never
+	// made.
+	PlanModeDryRun = "dry-run"
+	// PlanModeApprove is the plan mode in which planned operations are made
+	// once approved.
+	PlanModeApprove = "approve"
+)
+
+// AttributeDefinitionsAuto is the value of the AttributeDefinitionsAnnotation
+// deriving the attribute definitions of a Table from its key schemas.
+const AttributeDefinitionsAuto = "auto"
+
+// TagOwnershipManaged is the value of the TagOwnershipAnnotation making the
+// controller only manage the tags it applied.
+const TagOwnershipManaged = "managed"
+
+const (
+	// ReplicaDeletionPolicyDelete is the value of the
+	// ReplicaDeletionPolicyAnnotation deleting the replicas of a Table before
+	// the table.
+	ReplicaDeletionPolicyDelete = "delete"
+	// ReplicaDeletionPolicyRefuse is the value of the
+	// ReplicaDeletionPolicyAnnotation holding the deletion of a Table while it
+	// has replicas.
+	ReplicaDeletionPolicyRefuse = "refuse"
+)
@@ -0,0 +1,177 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The types in this file don't have a DynamoDB API counterpart, they describe
+// controller specific fields of the custom resources.
+
+// CapacityScheduleEntry sets the provisioned throughput of a table and of its
+// global secondary indexes, from each activation of its schedule until the
+// activation of another entry.
+type CapacityScheduleEntry struct {
+	// Name of the entry, reported in Status.ActiveCapacitySchedule while the
+	// entry is active.
+	Name *string `json:"name,omitempty"`
+	// Cron expression of the entry activations, with the minute, hour, day
+	// of month, month and day of week fields, e.g. "0 8 * * MON-FRI".
+	Schedule *string `json:"schedule,omitempty"`
+	// IANA name of the time zone of the schedule, e.g. "Europe/Paris".
+	// Defaults to UTC.
+	TimeZone *string `json:"timeZone,omitempty"`
+	// Provisioned throughput of the table while the entry is active. Unset
+	// capacity units default to the ones of Spec.ProvisionedThroughput.
+	ProvisionedThroughput *ProvisionedThroughput `json:"provisionedThroughput,omitempty"`
+	// Provisioned throughput of the global secondary indexes while the entry
+	// is active.
+	GlobalSecondaryIndexes []*IndexProvisionedThroughput `json:"globalSecondaryIndexes,omitempty"`
+}
+
+// FieldDrift describes a field whose desired value differs from the value
+// observed in DynamoDB.
+type FieldDrift struct {
+	// Path of the field in the resource Spec.
+	Path *string `json:"path,omitempty"`
+	// Desired value of the field, JSON encoded.
+	Desired *string `json:"desired,omitempty"`
+	// Observed value of the field, JSON encoded.
+	Observed *string `json:"observed,omitempty"`
+}
+
+// FieldTransition describes the progress of a field change DynamoDB doesn't
+// allow making in a single call, like changing the view type of an enabled
+// stream. The field is disabled first, and re-enabled with its new value once
+// DynamoDB reports it disabled.
+type FieldTransition struct {
+	// Path of the field in the resource Spec.
+	Path *string `json:"path,omitempty"`
+	// Current step of the transition, Disabling or Enabling.
+	Step *string `json:"step,omitempty"`
+	// Time the current step started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+}
+
+// IndexProvisionedThroughput is the provisioned throughput of a global
+// secondary index.
+type IndexProvisionedThroughput struct {
+	IndexName             *string                `json:"indexName,omitempty"`
+	ProvisionedThroughput *ProvisionedThroughput `json:"provisionedThroughput,omitempty"`
+}
+
+// MaintenanceWindow describes the recurring time windows in which the
+// controller makes the changes that put load on a table, like creating global
+// secondary indexes or switching the billing mode or the table class.
+type MaintenanceWindow struct {
+	// Cron expression of the window openings, with the minute, hour, day of
+	// month, month and day of week fields, e.g. "0 2 * * SAT".
+	Schedule *string `json:"schedule,omitempty"`
+	// How long the window stays open, e.g. "3h". Defaults to one hour.
+	Duration *string `json:"duration,omitempty"`
+	// IANA name of the time zone of the schedule, e.g. "Europe/Paris".
+	// Defaults to UTC.
+	TimeZone *string `json:"timeZone,omitempty"`
+}
+
+// PlannedOperation describes a change the controller makes to a DynamoDB
+// resource to converge it to its desired state.
+type PlannedOperation struct {
+	// Name of the DynamoDB API operation making the change, e.g. UpdateTable.
+	Operation *string `json:"operation,omitempty"`
+	// Short identifier of the change, e.g. DeleteGlobalSecondaryIndex.
+	Action *string `json:"action,omitempty"`
+	// Human readable description of the change.
+	Description *string `json:"description,omitempty"`
+}
+
+// ReplicaDeletionMode is what happens to the tables of the replicas of a
+// global table when it's deleted.
+// +kubebuilder:validation:Enum=Detach;DeleteTables
+type ReplicaDeletionMode string
+
+const (
+	ReplicaDeletionMode_Detach       ReplicaDeletionMode = "Detach"
+	ReplicaDeletionMode_DeleteTables ReplicaDeletionMode = "DeleteTables"
+)
+
+// ReplacementStrategy configures the replacement of a table whose key schema
+// or local secondary indexes change, which DynamoDB doesn't allow updating.
+// The controller creates a new table with a generated name and the new
+// schema, optionally copies the items of the old table, then manages the new
+// table in place of the old one.
+type ReplacementStrategy struct {
+	// How the items of the old table are copied to the new table: None or
+	// ScanAndWrite. ScanAndWrite scans the old table while it may still be
+	// written to, the items written during the copy can be lost. It requires
+	// the acknowledge-data-copy-write-loss annotation set to "true" on the
+	// table. Defaults to None.
+	DataCopy *string `json:"dataCopy,omitempty"`
+	// What happens to the old table once replaced: Retain or Delete. The old
+	// table is deleted once the switch to the new table is recorded, like the
+	// table itself: after its replicas and never while Backups or
+	// GlobalTables reference it. It is retained if the resource is deleted
+	// first. Defaults to Retain.
+	OldTablePolicy *string `json:"oldTablePolicy,omitempty"`
+	// Name of a ConfigMap, in the namespace of the table, whose tableName key
+	// is set to the name of the new table once it replaces the old one. The
+	// name is also reported in Status.PhysicalTableName, for FieldExports.
+	ConfigMapName *string `json:"configMapName,omitempty"`
+}
+
+// TableReplacement describes the progress of the replacement of a table.
+type TableReplacement struct {
+	// Current phase of the replacement: Creating, CopyingData,
+	// DeletingOldTable or Completed.
+	Phase *string `json:"phase,omitempty"`
+	// Generation of the resource the replacement was started for.
+	Generation *int64 `json:"generation,omitempty"`
+	// Name of the new table.
+	TableName *string `json:"tableName,omitempty"`
+	// Name of the replaced table.
+	PreviousTableName *string `json:"previousTableName,omitempty"`
+	// Number of items copied to the new table.
+	CopiedItems *int64 `json:"copiedItems,omitempty"`
+	// JSON encoded key of the last item copied to the new table.
+	CopyCursor *string `json:"copyCursor,omitempty"`
+	// Time the current phase started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+}
+
+// TableTemplate describes the tables the controller creates in the Regions of
+// a global table that have none.
+type TableTemplate struct {
+	AttributeDefinitions []*AttributeDefinition `json:"attributeDefinitions,omitempty"`
+	// PROVISIONED or PAY_PER_REQUEST. Defaults to PAY_PER_REQUEST.
+	BillingMode            *string                 `json:"billingMode,omitempty"`
+	GlobalSecondaryIndexes []*GlobalSecondaryIndex `json:"globalSecondaryIndexes,omitempty"`
+	KeySchema              []*KeySchemaElement     `json:"keySchema,omitempty"`
+	ProvisionedThroughput  *ProvisionedThroughput  `json:"provisionedThroughput,omitempty"`
+	SSESpecification       *SSESpecification       `json:"sseSpecification,omitempty"`
+	// Global tables require streams enabled with the NEW_AND_OLD_IMAGES view
+	// type, the default.
+	StreamSpecification *StreamSpecification `json:"streamSpecification,omitempty"`
+}
+
+// UpdatePlan is the ordered list of operations the controller makes to
+// converge a DynamoDB resource to its desired state.
+type UpdatePlan struct {
+	// Hash identifying the planned operations. Approving the plan is done
+	// by setting this value in the approved-plan annotation.
+	Hash *string `json:"hash,omitempty"`
+	// Generation of the resource the plan was computed for.
+	Generation *int64              `json:"generation,omitempty"`
+	Operations []*PlannedOperation `json:"operations,omitempty"`
+}
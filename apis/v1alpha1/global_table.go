@@ -28,9 +28,21 @@ type GlobalTableSpec struct {
 	// The global table name.
 	// +kubebuilder:validation:Required
 	GlobalTableName *string `json:"globalTableName"`
+	// What happens to the tables of the replicas when the global table is
+	// deleted: Detach removes the replicas from the global table and keeps
+	// their tables, DeleteTables deletes their tables once removed. By
+	// default, only the tables created from Spec.TableTemplate are deleted.
+	ReplicaDeletionMode *ReplicaDeletionMode `json:"replicaDeletionMode,omitempty"`
 	// The Regions where the global table needs to be created.
 	// +kubebuilder:validation:Required
 	ReplicationGroup []*Replica `json:"replicationGroup"`
+	// Template of the tables created in the Regions of the replication group
+	// that have none, before the global table is created. The tables created
+	// from the template are deleted with the global table.
+	TableTemplate *TableTemplate `json:"tableTemplate,omitempty"`
+	// A list of key-value pairs applied to the replica table of every Region
+	// of the global table.
+	Tags []*Tag `json:"tags,omitempty"`
 }
 
 // GlobalTableStatus defines the observed state of GlobalTable
@@ -60,6 +72,16 @@ type GlobalTableStatus struct {
 	//    * ACTIVE - The global table is ready for use.
 	// +kubebuilder:validation:Optional
 	GlobalTableStatus *string `json:"globalTableStatus,omitempty"`
+	// The replicas of the global table, with their status.
+	// +kubebuilder:validation:Optional
+	Replicas []*ReplicaDescription `json:"replicas,omitempty"`
+	// Regions of the tables of the removed replicas deleted with the global
+	// table, while it's being deleted.
+	// +kubebuilder:validation:Optional
+	TablesPendingDeletion []*string `json:"tablesPendingDeletion,omitempty"`
+	// Regions of the tables created from Spec.TableTemplate.
+	// +kubebuilder:validation:Optional
+	TemplateTableRegions []*string `json:"templateTableRegions,omitempty"`
 }
 
 // GlobalTable is the Schema for the GlobalTables API
@@ -35,6 +35,12 @@ type TableSpec struct {
 	//   - PAY_PER_REQUEST - We recommend using PAY_PER_REQUEST for unpredictable
 	//     workloads. PAY_PER_REQUEST sets the billing mode to On-Demand Mode (https://docs.aws.amazon.com/amazondynamodb/latest/developerguide/HowItWorks.ReadWriteCapacityMode.html#HowItWorks.OnDemand).
 	BillingMode *string `json:"billingMode,omitempty"`
+	// Provisioned throughputs of the table and of its global secondary indexes
+	// varying over time. The active entry, the one with the most recent
+	// activation, overrides the ProvisionedThroughput fields of the table and
+	// of its global secondary indexes. The table is updated when each entry
+	// activates.
+	CapacitySchedule []*CapacityScheduleEntry `json:"capacitySchedule,omitempty"`
 	// Represents the settings used to enable point in time recovery.
 	ContinuousBackups *PointInTimeRecoverySpecification `json:"continuousBackups,omitempty"`
 	// One or more global secondary indexes (the maximum is 20) to be created on
@@ -125,6 +131,10 @@ type TableSpec struct {
 	//     the same attribute into two different indexes, this counts as two distinct
 	//     attributes when determining the total.
 	LocalSecondaryIndexes []*LocalSecondaryIndex `json:"localSecondaryIndexes,omitempty"`
+	// Time windows in which the controller makes the changes that put load on
+	// the table. Defaults to the maintenance-window annotation of the namespace.
+	// Other changes are made immediately.
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
 	// Represents the provisioned throughput settings for a specified table or index.
 	// The settings can be modified using the UpdateTable operation.
 	//
@@ -135,6 +145,11 @@ type TableSpec struct {
 	// Account, and Table Quotas (https://docs.aws.amazon.com/amazondynamodb/latest/developerguide/Limits.html)
 	// in the Amazon DynamoDB Developer Guide.
 	ProvisionedThroughput *ProvisionedThroughput `json:"provisionedThroughput,omitempty"`
+	// Opts in the replacement of the table when its key schema or local
+	// secondary indexes change. Spec.TableName is left unchanged, the name of
+	// the new table is reported in Status.PhysicalTableName once it replaces
+	// the old one.
+	ReplacementStrategy *ReplacementStrategy `json:"replacementStrategy,omitempty"`
 	// Represents the settings used to enable server-side encryption.
 	SSESpecification *SSESpecification `json:"sseSpecification,omitempty"`
 	// The settings for DynamoDB Streams on the table. These settings consist of:
@@ -177,13 +192,28 @@ type TableStatus struct {
 	// resource
 	// +kubebuilder:validation:Optional
 	Conditions []*ackv1alpha1.Condition `json:"conditions"`
+	// Name of the active capacity schedule entry.
+	// +kubebuilder:validation:Optional
+	ActiveCapacitySchedule *string `json:"activeCapacitySchedule,omitempty"`
 	// Contains information about the table archive.
 	// +kubebuilder:validation:Optional
 	ArchivalSummary *ArchivalSummary `json:"archivalSummary,omitempty"`
+	// Contains the details of the table billing mode, including the time of the
+	// last switch to PAY_PER_REQUEST.
+	// +kubebuilder:validation:Optional
+	BillingModeSummary *BillingModeSummary `json:"billingModeSummary,omitempty"`
 	// The date and time when the table was created, in UNIX epoch time (http://www.epochconverter.com/)
 	// format.
 	// +kubebuilder:validation:Optional
 	CreationDateTime *metav1.Time `json:"creationDateTime,omitempty"`
+	// Differences between the desired and observed state of an observe-only
+	// table.
+	// +kubebuilder:validation:Optional
+	Drift []*FieldDrift `json:"drift,omitempty"`
+	// Tags of a table with managed tag ownership that were not applied by
+	// the controller, and that it leaves alone.
+	// +kubebuilder:validation:Optional
+	ForeignTags []*Tag `json:"foreignTags,omitempty"`
 	// +kubebuilder:validation:Optional
 	GlobalSecondaryIndexesDescriptions []*GlobalSecondaryIndexDescription `json:"globalSecondaryIndexesDescriptions,omitempty"`
 	// Represents the version of global tables (https://docs.aws.amazon.com/amazondynamodb/latest/developerguide/GlobalTables.html)
@@ -212,6 +242,30 @@ type TableStatus struct {
 	//    * StreamLabel
 	// +kubebuilder:validation:Optional
 	LatestStreamLabel *string `json:"latestStreamLabel,omitempty"`
+	// Keys of the tags applied by the controller to a table with managed tag
+	// ownership.
+	// +kubebuilder:validation:Optional
+	ManagedTagKeys []*string `json:"managedTagKeys,omitempty"`
+	// Opening time of the next maintenance window, when changes are waiting
+	// for it.
+	// +kubebuilder:validation:Optional
+	NextMaintenanceWindow *metav1.Time `json:"nextMaintenanceWindow,omitempty"`
+	// Name of the DynamoDB table of the resource: Spec.TableName until the
+	// table is replaced, then the name of the new table.
+	// +kubebuilder:validation:Optional
+	PhysicalTableName *string `json:"physicalTableName,omitempty"`
+	// Operations planned to converge the table to its desired state, when the
+	// table has the plan-mode annotation or destructive operations are planned.
+	// +kubebuilder:validation:Optional
+	Plan *UpdatePlan `json:"plan,omitempty"`
+	// The provisioned throughput of the table, along with data about increases
+	// and decreases.
+	// +kubebuilder:validation:Optional
+	ProvisionedThroughputDescription *ProvisionedThroughputDescription `json:"provisionedThroughputDescription,omitempty"`
+	// Progress of the replacement of the table, when its key schema or local
+	// secondary indexes changed.
+	// +kubebuilder:validation:Optional
+	Replacement *TableReplacement `json:"replacement,omitempty"`
 	// Represents replicas of the table.
 	// +kubebuilder:validation:Optional
 	Replicas []*ReplicaDescription `json:"replicas,omitempty"`
@@ -248,6 +302,11 @@ type TableStatus struct {
 	//    information.
 	// +kubebuilder:validation:Optional
 	TableStatus *string `json:"tableStatus,omitempty"`
+	// Changes made in several steps, like changing the view type of an
+	// enabled stream or the attribute of an enabled time to live, which must
+	// be disabled first.
+	// +kubebuilder:validation:Optional
+	Transitions []*FieldTransition `json:"transitions,omitempty"`
 }
 
 // Table is the Schema for the Tables API
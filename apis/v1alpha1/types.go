@@ -459,7 +459,8 @@ type Put struct {
 
 // Represents the properties of a replica.
 type Replica struct {
-	RegionName *string `json:"regionName,omitempty"`
+	RegionName *string                                  `json:"regionName,omitempty"`
+	TableRef   *ackv1alpha1.AWSResourceReferenceWrapper `json:"tableRef,omitempty"`
 }
 
 // Represents the auto scaling settings of the replica.
@@ -606,9 +607,10 @@ type SSEDescription struct {
 
 // Represents the settings used to enable server-side encryption.
 type SSESpecification struct {
-	Enabled        *bool   `json:"enabled,omitempty"`
-	KMSMasterKeyID *string `json:"kmsMasterKeyID,omitempty"`
-	SSEType        *string `json:"sseType,omitempty"`
+	Enabled         *bool                                    `json:"enabled,omitempty"`
+	KMSMasterKeyID  *string                                  `json:"kmsMasterKeyID,omitempty"`
+	KMSMasterKeyRef *ackv1alpha1.AWSResourceReferenceWrapper `json:"kmsMasterKeyRef,omitempty"`
+	SSEType         *string                                  `json:"sseType,omitempty"`
 }
 
 // Contains the details of the table when the backup was created.
@@ -527,6 +527,52 @@ func (in *BillingModeSummary) DeepCopy() *BillingModeSummary {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapacityScheduleEntry) DeepCopyInto(out *CapacityScheduleEntry) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(string)
+		**out = **in
+	}
+	if in.TimeZone != nil {
+		in, out := &in.TimeZone, &out.TimeZone
+		*out = new(string)
+		**out = **in
+	}
+	if in.ProvisionedThroughput != nil {
+		in, out := &in.ProvisionedThroughput, &out.ProvisionedThroughput
+		*out = new(ProvisionedThroughput)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GlobalSecondaryIndexes != nil {
+		in, out := &in.GlobalSecondaryIndexes, &out.GlobalSecondaryIndexes
+		*out = make([]*IndexProvisionedThroughput, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(IndexProvisionedThroughput)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityScheduleEntry.
+func (in *CapacityScheduleEntry) DeepCopy() *CapacityScheduleEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacityScheduleEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConditionCheck) DeepCopyInto(out *ConditionCheck) {
 	*out = *in
@@ -874,6 +920,65 @@ func (in *Get) DeepCopy() *Get {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FieldDrift) DeepCopyInto(out *FieldDrift) {
+	*out = *in
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+	if in.Desired != nil {
+		in, out := &in.Desired, &out.Desired
+		*out = new(string)
+		**out = **in
+	}
+	if in.Observed != nil {
+		in, out := &in.Observed, &out.Observed
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FieldDrift.
+func (in *FieldDrift) DeepCopy() *FieldDrift {
+	if in == nil {
+		return nil
+	}
+	out := new(FieldDrift)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FieldTransition) DeepCopyInto(out *FieldTransition) {
+	*out = *in
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+	if in.Step != nil {
+		in, out := &in.Step, &out.Step
+		*out = new(string)
+		**out = **in
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FieldTransition.
+func (in *FieldTransition) DeepCopy() *FieldTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(FieldTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GlobalSecondaryIndex) DeepCopyInto(out *GlobalSecondaryIndex) {
 	*out = *in
@@ -1209,6 +1314,11 @@ func (in *GlobalTableSpec) DeepCopyInto(out *GlobalTableSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ReplicaDeletionMode != nil {
+		in, out := &in.ReplicaDeletionMode, &out.ReplicaDeletionMode
+		*out = new(ReplicaDeletionMode)
+		**out = **in
+	}
 	if in.ReplicationGroup != nil {
 		in, out := &in.ReplicationGroup, &out.ReplicationGroup
 		*out = make([]*Replica, len(*in))
@@ -1220,6 +1330,22 @@ func (in *GlobalTableSpec) DeepCopyInto(out *GlobalTableSpec) {
 			}
 		}
 	}
+	if in.TableTemplate != nil {
+		in, out := &in.TableTemplate, &out.TableTemplate
+		*out = new(TableTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]*Tag, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Tag)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalTableSpec.
@@ -1260,6 +1386,39 @@ func (in *GlobalTableStatus) DeepCopyInto(out *GlobalTableStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = make([]*ReplicaDescription, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ReplicaDescription)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.TablesPendingDeletion != nil {
+		in, out := &in.TablesPendingDeletion, &out.TablesPendingDeletion
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.TemplateTableRegions != nil {
+		in, out := &in.TemplateTableRegions, &out.TemplateTableRegions
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalTableStatus.
@@ -1353,6 +1512,31 @@ func (in *ImportTableDescription) DeepCopy() *ImportTableDescription {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexProvisionedThroughput) DeepCopyInto(out *IndexProvisionedThroughput) {
+	*out = *in
+	if in.IndexName != nil {
+		in, out := &in.IndexName, &out.IndexName
+		*out = new(string)
+		**out = **in
+	}
+	if in.ProvisionedThroughput != nil {
+		in, out := &in.ProvisionedThroughput, &out.ProvisionedThroughput
+		*out = new(ProvisionedThroughput)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexProvisionedThroughput.
+func (in *IndexProvisionedThroughput) DeepCopy() *IndexProvisionedThroughput {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexProvisionedThroughput)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeySchemaElement) DeepCopyInto(out *KeySchemaElement) {
 	*out = *in
@@ -1526,6 +1710,66 @@ func (in *LocalSecondaryIndexInfo) DeepCopy() *LocalSecondaryIndexInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(string)
+		**out = **in
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(string)
+		**out = **in
+	}
+	if in.TimeZone != nil {
+		in, out := &in.TimeZone, &out.TimeZone
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlannedOperation) DeepCopyInto(out *PlannedOperation) {
+	*out = *in
+	if in.Operation != nil {
+		in, out := &in.Operation, &out.Operation
+		*out = new(string)
+		**out = **in
+	}
+	if in.Action != nil {
+		in, out := &in.Action, &out.Action
+		*out = new(string)
+		**out = **in
+	}
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlannedOperation.
+func (in *PlannedOperation) DeepCopy() *PlannedOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(PlannedOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PointInTimeRecoveryDescription) DeepCopyInto(out *PointInTimeRecoveryDescription) {
 	*out = *in
@@ -1703,6 +1947,36 @@ func (in *Put) DeepCopy() *Put {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplacementStrategy) DeepCopyInto(out *ReplacementStrategy) {
+	*out = *in
+	if in.DataCopy != nil {
+		in, out := &in.DataCopy, &out.DataCopy
+		*out = new(string)
+		**out = **in
+	}
+	if in.OldTablePolicy != nil {
+		in, out := &in.OldTablePolicy, &out.OldTablePolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.ConfigMapName != nil {
+		in, out := &in.ConfigMapName, &out.ConfigMapName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplacementStrategy.
+func (in *ReplacementStrategy) DeepCopy() *ReplacementStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplacementStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Replica) DeepCopyInto(out *Replica) {
 	*out = *in
@@ -1711,6 +1985,11 @@ func (in *Replica) DeepCopyInto(out *Replica) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.TableRef != nil {
+		in, out := &in.TableRef, &out.TableRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Replica.
@@ -2194,6 +2473,11 @@ func (in *SSESpecification) DeepCopyInto(out *SSESpecification) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.KMSMasterKeyRef != nil {
+		in, out := &in.KMSMasterKeyRef, &out.KMSMasterKeyRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SSEType != nil {
 		in, out := &in.SSEType, &out.SSEType
 		*out = new(string)
@@ -2668,6 +2952,55 @@ func (in *TableList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TableReplacement) DeepCopyInto(out *TableReplacement) {
+	*out = *in
+	if in.Phase != nil {
+		in, out := &in.Phase, &out.Phase
+		*out = new(string)
+		**out = **in
+	}
+	if in.Generation != nil {
+		in, out := &in.Generation, &out.Generation
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TableName != nil {
+		in, out := &in.TableName, &out.TableName
+		*out = new(string)
+		**out = **in
+	}
+	if in.PreviousTableName != nil {
+		in, out := &in.PreviousTableName, &out.PreviousTableName
+		*out = new(string)
+		**out = **in
+	}
+	if in.CopiedItems != nil {
+		in, out := &in.CopiedItems, &out.CopiedItems
+		*out = new(int64)
+		**out = **in
+	}
+	if in.CopyCursor != nil {
+		in, out := &in.CopyCursor, &out.CopyCursor
+		*out = new(string)
+		**out = **in
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TableReplacement.
+func (in *TableReplacement) DeepCopy() *TableReplacement {
+	if in == nil {
+		return nil
+	}
+	out := new(TableReplacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TableSpec) DeepCopyInto(out *TableSpec) {
 	*out = *in
@@ -2687,6 +3020,17 @@ func (in *TableSpec) DeepCopyInto(out *TableSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.CapacitySchedule != nil {
+		in, out := &in.CapacitySchedule, &out.CapacitySchedule
+		*out = make([]*CapacityScheduleEntry, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(CapacityScheduleEntry)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 	if in.ContinuousBackups != nil {
 		in, out := &in.ContinuousBackups, &out.ContinuousBackups
 		*out = new(PointInTimeRecoverySpecification)
@@ -2725,11 +3069,21 @@ func (in *TableSpec) DeepCopyInto(out *TableSpec) {
 			}
 		}
 	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ProvisionedThroughput != nil {
 		in, out := &in.ProvisionedThroughput, &out.ProvisionedThroughput
 		*out = new(ProvisionedThroughput)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ReplacementStrategy != nil {
+		in, out := &in.ReplacementStrategy, &out.ReplacementStrategy
+		*out = new(ReplacementStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SSESpecification != nil {
 		in, out := &in.SSESpecification, &out.SSESpecification
 		*out = new(SSESpecification)
@@ -2797,15 +3151,47 @@ func (in *TableStatus) DeepCopyInto(out *TableStatus) {
 			}
 		}
 	}
+	if in.ActiveCapacitySchedule != nil {
+		in, out := &in.ActiveCapacitySchedule, &out.ActiveCapacitySchedule
+		*out = new(string)
+		**out = **in
+	}
 	if in.ArchivalSummary != nil {
 		in, out := &in.ArchivalSummary, &out.ArchivalSummary
 		*out = new(ArchivalSummary)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.BillingModeSummary != nil {
+		in, out := &in.BillingModeSummary, &out.BillingModeSummary
+		*out = new(BillingModeSummary)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CreationDateTime != nil {
 		in, out := &in.CreationDateTime, &out.CreationDateTime
 		*out = (*in).DeepCopy()
 	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = make([]*FieldDrift, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(FieldDrift)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.ForeignTags != nil {
+		in, out := &in.ForeignTags, &out.ForeignTags
+		*out = make([]*Tag, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Tag)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 	if in.GlobalSecondaryIndexesDescriptions != nil {
 		in, out := &in.GlobalSecondaryIndexesDescriptions, &out.GlobalSecondaryIndexesDescriptions
 		*out = make([]*GlobalSecondaryIndexDescription, len(*in))
@@ -2837,6 +3223,41 @@ func (in *TableStatus) DeepCopyInto(out *TableStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ManagedTagKeys != nil {
+		in, out := &in.ManagedTagKeys, &out.ManagedTagKeys
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.NextMaintenanceWindow != nil {
+		in, out := &in.NextMaintenanceWindow, &out.NextMaintenanceWindow
+		*out = (*in).DeepCopy()
+	}
+	if in.PhysicalTableName != nil {
+		in, out := &in.PhysicalTableName, &out.PhysicalTableName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = new(UpdatePlan)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProvisionedThroughputDescription != nil {
+		in, out := &in.ProvisionedThroughputDescription, &out.ProvisionedThroughputDescription
+		*out = new(ProvisionedThroughputDescription)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Replacement != nil {
+		in, out := &in.Replacement, &out.Replacement
+		*out = new(TableReplacement)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Replicas != nil {
 		in, out := &in.Replicas, &out.Replicas
 		*out = make([]*ReplicaDescription, len(*in))
@@ -2868,6 +3289,17 @@ func (in *TableStatus) DeepCopyInto(out *TableStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Transitions != nil {
+		in, out := &in.Transitions, &out.Transitions
+		*out = make([]*FieldTransition, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(FieldTransition)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TableStatus.
@@ -2880,6 +3312,74 @@ func (in *TableStatus) DeepCopy() *TableStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TableTemplate) DeepCopyInto(out *TableTemplate) {
+	*out = *in
+	if in.AttributeDefinitions != nil {
+		in, out := &in.AttributeDefinitions, &out.AttributeDefinitions
+		*out = make([]*AttributeDefinition, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(AttributeDefinition)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.BillingMode != nil {
+		in, out := &in.BillingMode, &out.BillingMode
+		*out = new(string)
+		**out = **in
+	}
+	if in.GlobalSecondaryIndexes != nil {
+		in, out := &in.GlobalSecondaryIndexes, &out.GlobalSecondaryIndexes
+		*out = make([]*GlobalSecondaryIndex, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(GlobalSecondaryIndex)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.KeySchema != nil {
+		in, out := &in.KeySchema, &out.KeySchema
+		*out = make([]*KeySchemaElement, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(KeySchemaElement)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.ProvisionedThroughput != nil {
+		in, out := &in.ProvisionedThroughput, &out.ProvisionedThroughput
+		*out = new(ProvisionedThroughput)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SSESpecification != nil {
+		in, out := &in.SSESpecification, &out.SSESpecification
+		*out = new(SSESpecification)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StreamSpecification != nil {
+		in, out := &in.StreamSpecification, &out.StreamSpecification
+		*out = new(StreamSpecification)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TableTemplate.
+func (in *TableTemplate) DeepCopy() *TableTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(TableTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Tag) DeepCopyInto(out *Tag) {
 	*out = *in
@@ -3000,6 +3500,42 @@ func (in *UpdateGlobalSecondaryIndexAction) DeepCopy() *UpdateGlobalSecondaryInd
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpdatePlan) DeepCopyInto(out *UpdatePlan) {
+	*out = *in
+	if in.Hash != nil {
+		in, out := &in.Hash, &out.Hash
+		*out = new(string)
+		**out = **in
+	}
+	if in.Generation != nil {
+		in, out := &in.Generation, &out.Generation
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Operations != nil {
+		in, out := &in.Operations, &out.Operations
+		*out = make([]*PlannedOperation, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(PlannedOperation)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpdatePlan.
+func (in *UpdatePlan) DeepCopy() *UpdatePlan {
+	if in == nil {
+		return nil
+	}
+	out := new(UpdatePlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UpdateReplicationGroupMemberAction) DeepCopyInto(out *UpdateReplicationGroupMemberAction) {
 	*out = *in
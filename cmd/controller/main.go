@@ -39,6 +39,8 @@ import (
 	_ "github.com/aws-controllers-k8s/dynamodb-controller/pkg/resource/global_table"
 	_ "github.com/aws-controllers-k8s/dynamodb-controller/pkg/resource/table"
 
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/approval"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/throttle"
 	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/version"
 )
 
@@ -60,6 +62,10 @@ func init() {
 func main() {
 	var ackCfg ackcfg.Config
 	ackCfg.BindFlags()
+	var throttleCfg throttle.Config
+	throttleCfg.BindFlags()
+	var approvalCfg approval.Config
+	approvalCfg.BindFlags()
 	flag.Parse()
 	ackCfg.SetupLogger()
 
@@ -76,6 +82,22 @@ func main() {
 		)
 		os.Exit(1)
 	}
+	if err := throttleCfg.Validate(); err != nil {
+		setupLog.Error(
+			err, "Unable to create controller manager",
+			"aws.service", awsServiceAlias,
+		)
+		os.Exit(1)
+	}
+	throttle.Setup(throttleCfg)
+	if err := approvalCfg.Validate(); err != nil {
+		setupLog.Error(
+			err, "Unable to create controller manager",
+			"aws.service", awsServiceAlias,
+		)
+		os.Exit(1)
+	}
+	approval.Setup(approvalCfg)
 
 	host, port, err := ackrtutil.GetHostPort(ackCfg.WebhookServerAddr)
 	if err != nil {
@@ -86,7 +108,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	mgr, err := ctrlrt.NewManager(ctrlrt.GetConfigOrDie(), ctrlrt.Options{
+	mgr, err := newManager(ctrlrt.GetConfigOrDie(), ctrlrt.Options{
 		Scheme:             scheme,
 		Port:               port,
 		Host:               host,
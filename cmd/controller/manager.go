@@ -0,0 +1,64 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/dependents"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/events"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/publish"
+)
+
+// newManager returns a new controller manager whose reconciliations get the
+// Kubernetes client and the event recorder from their context. The manager
+// builds the base context of its runnables while it is created, so the
+// client and the recorder are built from the supplied config beforehand.
+func newManager(cfg *rest.Config, options ctrlrt.Options) (ctrlrt.Manager, error) {
+	mapper, err := apiutil.NewDynamicRESTMapper(cfg, apiutil.WithLazyDiscovery)
+	if err != nil {
+		return nil, err
+	}
+	kc, err := client.New(cfg, client.Options{Scheme: options.Scheme, Mapper: mapper})
+	if err != nil {
+		return nil, err
+	}
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{
+		Interface: clientset.CoreV1().Events(""),
+	})
+	recorder := broadcaster.NewRecorder(options.Scheme, corev1.EventSource{
+		Component: awsServiceAlias + "-controller",
+	})
+
+	options.BaseContext = func() context.Context {
+		ctx := publish.NewContext(context.Background(), kc)
+		ctx = dependents.NewContext(ctx, kc)
+		return events.NewContext(ctx, recorder)
+	}
+	return ctrlrt.NewManager(cfg, options)
+}
@@ -46,3 +46,8 @@ If release name contains chart name it will be used as a full name.
 {{- define "aws.credentials.path" -}}
 {{- printf "%s/%s" (include "aws.credentials.secret_mount_path" .) .Values.aws.credentials.secretKey -}}
 {{- end -}}
+
+{{/* The name of the Secret holding the serving certificate of the webhooks */}}
+{{- define "webhook.secret-name" -}}
+{{- printf "%s-webhook-server-cert" (include "app.fullname" . | trunc 44 | trimSuffix "-") -}}
+{{- end -}}
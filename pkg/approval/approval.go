@@ -0,0 +1,111 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package approval holds the configuration of the operations the controller
+// only makes once a user approved them.
+package approval
+
+import (
+	"sync"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+)
+
+// ConditionTypePendingApproval is the type of the condition reporting that
+// planned operations are waiting for approval.
+const ConditionTypePendingApproval ackv1alpha1.ConditionType = "PendingApproval"
+
+// Actions identify the changes the controller makes to DynamoDB resources.
+const (
+	ActionAddTags                     = "AddTags"
+	ActionRemoveTags                  = "RemoveTags"
+	ActionUpdateTimeToLive            = "UpdateTimeToLive"
+	ActionUpdateSSESpecification      = "UpdateSSESpecification"
+	ActionUpdateBillingMode           = "UpdateBillingMode"
+	ActionUpdateTableClass            = "UpdateTableClass"
+	ActionUpdatePointInTimeRecovery   = "UpdatePointInTimeRecovery"
+	ActionUpdateStreamSpecification   = "UpdateStreamSpecification"
+	ActionUpdateProvisionedThroughput = "UpdateProvisionedThroughput"
+	ActionCreateGlobalSecondaryIndex  = "CreateGlobalSecondaryIndex"
+	ActionUpdateGlobalSecondaryIndex  = "UpdateGlobalSecondaryIndex"
+	ActionDeleteGlobalSecondaryIndex  = "DeleteGlobalSecondaryIndex"
+	ActionDeleteReplica               = "DeleteReplica"
+	ActionReplaceTable                = "ReplaceTable"
+)
+
+var (
+	// KnownActions are all the actions the controller makes.
+	KnownActions = []string{
+		ActionAddTags,
+		ActionRemoveTags,
+		ActionUpdateTimeToLive,
+		ActionUpdateSSESpecification,
+		ActionUpdateBillingMode,
+		ActionUpdateTableClass,
+		ActionUpdatePointInTimeRecovery,
+		ActionUpdateStreamSpecification,
+		ActionUpdateProvisionedThroughput,
+		ActionCreateGlobalSecondaryIndex,
+		ActionUpdateGlobalSecondaryIndex,
+		ActionDeleteGlobalSecondaryIndex,
+		ActionDeleteReplica,
+		ActionReplaceTable,
+	}
+	// DefaultDestructiveActions are the actions held back until approved
+	// when the destructive operations aren't configured.
+	DefaultDestructiveActions = []string{
+		ActionDeleteGlobalSecondaryIndex,
+		ActionUpdateBillingMode,
+		ActionUpdateTableClass,
+		ActionUpdateSSESpecification,
+		ActionDeleteReplica,
+		ActionReplaceTable,
+	}
+)
+
+var (
+	mu          sync.RWMutex
+	destructive = toSet(DefaultDestructiveActions)
+)
+
+// Setup configures the actions held back until approved.
+func Setup(cfg Config) {
+	mu.Lock()
+	defer mu.Unlock()
+	destructive = toSet(cfg.DestructiveOperations)
+}
+
+// IsDestructive returns true if the supplied action must be approved before
+// being made.
+func IsDestructive(action string) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return destructive[action]
+}
+
+func isKnownAction(action string) bool {
+	for _, known := range KnownActions {
+		if action == known {
+			return true
+		}
+	}
+	return false
+}
+
+func toSet(actions []string) map[string]bool {
+	res := make(map[string]bool, len(actions))
+	for _, action := range actions {
+		res[action] = true
+	}
+	return res
+}
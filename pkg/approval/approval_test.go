@@ -0,0 +1,54 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package approval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+func Test_Config_Validate(t *testing.T) {
+	require.NoError(t, (&Config{DestructiveOperations: DefaultDestructiveActions}).Validate())
+	require.NoError(t, (&Config{}).Validate())
+	require.Error(t, (&Config{DestructiveOperations: []string{"DropTable"}}).Validate())
+}
+
+func Test_IsDestructive(t *testing.T) {
+	require.True(t, IsDestructive(ActionUpdateBillingMode))
+	require.False(t, IsDestructive(ActionAddTags))
+
+	Setup(Config{DestructiveOperations: []string{ActionAddTags}})
+	defer Setup(Config{DestructiveOperations: DefaultDestructiveActions})
+	require.False(t, IsDestructive(ActionUpdateBillingMode))
+	require.True(t, IsDestructive(ActionAddTags))
+}
+
+func Test_PlanHash(t *testing.T) {
+	ops := []*v1alpha1.PlannedOperation{
+		NewPlannedOperation("UntagResource", ActionRemoveTags, "remove tags k1"),
+		NewPlannedOperation("UpdateTable", ActionUpdateBillingMode, "BillingMode: PROVISIONED -> PAY_PER_REQUEST"),
+	}
+	require.Equal(t, PlanHash(ops, 2), PlanHash(ops, 2))
+	require.NotEqual(t, PlanHash(ops, 2), PlanHash(ops, 3))
+	require.NotEqual(t, PlanHash(ops, 2), PlanHash(ops[1:], 2))
+
+	drifted := []*v1alpha1.PlannedOperation{
+		ops[0],
+		NewPlannedOperation("UpdateTable", ActionUpdateBillingMode, "BillingMode: PAY_PER_REQUEST"),
+	}
+	require.Equal(t, PlanHash(ops, 2), PlanHash(drifted, 2))
+}
@@ -0,0 +1,52 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package approval
+
+import (
+	"fmt"
+
+	flag "github.com/spf13/pflag"
+)
+
+const flagDestructiveOperations = "destructive-operations"
+
+// Config contains the approval related configuration of the controller.
+type Config struct {
+	// DestructiveOperations are the actions the controller holds back until
+	// they are approved.
+	DestructiveOperations []string
+}
+
+// BindFlags defines CLI/runtime configuration options
+func (cfg *Config) BindFlags() {
+	flag.StringSliceVar(
+		&cfg.DestructiveOperations, flagDestructiveOperations,
+		DefaultDestructiveActions,
+		"The operations the controller only makes once they are approved with the approved-plan annotation."+
+			fmt.Sprintf(" Valid values are %v.", KnownActions),
+	)
+}
+
+// Validate ensures the options are valid
+func (cfg *Config) Validate() error {
+	for _, action := range cfg.DestructiveOperations {
+		if !isKnownAction(action) {
+			return fmt.Errorf(
+				"invalid value for flag '%s': unknown operation '%s'",
+				flagDestructiveOperations, action,
+			)
+		}
+	}
+	return nil
+}
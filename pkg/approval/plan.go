@@ -0,0 +1,57 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package approval
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/aws/aws-sdk-go/aws"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+// NewPlannedOperation returns a new PlannedOperation.
+func NewPlannedOperation(operation, action, description string) *v1alpha1.PlannedOperation {
+	return &v1alpha1.PlannedOperation{
+		Operation:   aws.String(operation),
+		Action:      aws.String(action),
+		Description: aws.String(description),
+	}
+}
+
+// PlanHash returns the hash identifying the supplied operations planned for
+// the supplied generation of a resource. An approved plan isn't approved for
+// later generations, even if they plan the same operations. The
+// descriptions of the operations are left out of the hash: they mention the
+// observed values, and the plan stays approved when these drift.
+func PlanHash(ops []*v1alpha1.PlannedOperation, generation int64) string {
+	type change struct {
+		Operation string
+		Action    string
+	}
+	changes := make([]change, 0, len(ops))
+	for _, op := range ops {
+		changes = append(changes, change{
+			aws.StringValue(op.Operation), aws.StringValue(op.Action),
+		})
+	}
+	b, _ := json.Marshal(struct {
+		Generation int64
+		Changes    []change
+	}{generation, changes})
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])[:16]
+}
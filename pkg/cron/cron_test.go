@@ -0,0 +1,83 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Parse(t *testing.T) {
+	for _, expr := range []string{
+		"* * * * *", "0 2 * * SAT", "*/15 1-5 1,15 JAN-MAR MON-FRI", "30 4 * * 7",
+	} {
+		_, err := Parse(expr)
+		require.NoError(t, err, expr)
+	}
+	for _, expr := range []string{
+		"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *",
+		"* * * * 8", "*/0 * * * *", "5-1 * * * *", "a * * * *",
+	} {
+		_, err := Parse(expr)
+		require.Error(t, err, expr)
+	}
+}
+
+func Test_Schedule_Next(t *testing.T) {
+	// Saturday
+	now := time.Date(2023, time.March, 4, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2023, time.March, 4, 10, 31, 0, 0, time.UTC)},
+		{"0 2 * * SAT", time.Date(2023, time.March, 11, 2, 0, 0, 0, time.UTC)},
+		{"45 10 * * *", time.Date(2023, time.March, 4, 10, 45, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 12 * * 0", time.Date(2023, time.March, 5, 12, 0, 0, 0, time.UTC)},
+		// Day of month or day of week
+		{"0 0 10 * MON", time.Date(2023, time.March, 6, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 2 *", time.Time{}},
+	}
+	for _, tt := range tests {
+		s, err := Parse(tt.expr)
+		require.NoError(t, err)
+		require.Equal(t, tt.want, s.Next(now), tt.expr)
+	}
+}
+
+func Test_Schedule_Prev(t *testing.T) {
+	// Saturday
+	now := time.Date(2023, time.March, 4, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", now},
+		{"0 2 * * SAT", time.Date(2023, time.March, 4, 2, 0, 0, 0, time.UTC)},
+		{"45 10 * * *", time.Date(2023, time.March, 3, 10, 45, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 20 * * FRI", time.Date(2023, time.March, 3, 20, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 2 *", time.Time{}},
+	}
+	for _, tt := range tests {
+		s, err := Parse(tt.expr)
+		require.NoError(t, err)
+		require.Equal(t, tt.want, s.Prev(now), tt.expr)
+	}
+}
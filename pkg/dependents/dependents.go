@@ -0,0 +1,127 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package dependents finds the resources depending on a DynamoDB resource,
+// which must be deleted before it.
+package dependents
+
+import (
+	"context"
+	"fmt"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws/aws-sdk-go/aws"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+// readerKey is the key of the Kubernetes reader in the contexts.
+type readerKey struct{}
+
+// NewContext returns a copy of the supplied context carrying the reader
+// listing the dependents.
+func NewContext(ctx context.Context, r client.Reader) context.Context {
+	return context.WithValue(ctx, readerKey{}, r)
+}
+
+// OfTable returns the kinds and names of the Backups and GlobalTables of the
+// namespace of the supplied table that reference it, listed with the reader
+// carried by the supplied context: the Backups of its DynamoDB table in its
+// Region and the GlobalTables with a replica in its Region. Resources already
+// being deleted are left out.
+func OfTable(ctx context.Context, ko *v1alpha1.Table) ([]string, error) {
+	tableName := aws.StringValue(ko.Status.PhysicalTableName)
+	if tableName == "" {
+		tableName = aws.StringValue(ko.Spec.TableName)
+	}
+	return ofTable(ctx, ko, tableName, true)
+}
+
+// OfReplacedTable returns the kinds and names of the Backups and GlobalTables
+// of the namespace of the supplied table that reference, by its name, the
+// DynamoDB table with the supplied name it replaced. Table references only
+// reference the current table of the supplied table.
+func OfReplacedTable(ctx context.Context, ko *v1alpha1.Table, tableName string) ([]string, error) {
+	return ofTable(ctx, ko, tableName, false)
+}
+
+// ofTable returns the resources referencing the DynamoDB table with the
+// supplied name, of the supplied table, and through table references to the
+// supplied table if byRef is true.
+func ofTable(ctx context.Context, ko *v1alpha1.Table, tableName string, byRef bool) ([]string, error) {
+	reader, _ := ctx.Value(readerKey{}).(client.Reader)
+	if reader == nil {
+		return nil, fmt.Errorf("cannot list the resources referencing table %s/%s: no Kubernetes reader", ko.Namespace, ko.Name)
+	}
+	var region string
+	if ko.Status.ACKResourceMetadata != nil && ko.Status.ACKResourceMetadata.Region != nil {
+		region = string(*ko.Status.ACKResourceMetadata.Region)
+	}
+
+	var res []string
+	var backups v1alpha1.BackupList
+	if err := reader.List(ctx, &backups, client.InNamespace(ko.Namespace)); err != nil {
+		return nil, err
+	}
+	for _, backup := range backups.Items {
+		if backup.DeletionTimestamp != nil || aws.StringValue(backup.Spec.TableName) != tableName {
+			continue
+		}
+		if backupRegion := regionOfBackup(&backup); region == "" || backupRegion == "" || backupRegion == region {
+			res = append(res, "Backup/"+backup.Name)
+		}
+	}
+
+	var globalTables v1alpha1.GlobalTableList
+	if err := reader.List(ctx, &globalTables, client.InNamespace(ko.Namespace)); err != nil {
+		return nil, err
+	}
+	for _, globalTable := range globalTables.Items {
+		if globalTable.DeletionTimestamp == nil && referencesTable(&globalTable, ko, tableName, region, byRef) {
+			res = append(res, "GlobalTable/"+globalTable.Name)
+		}
+	}
+	return res, nil
+}
+
+// regionOfBackup returns the Region of the supplied backup: the one it was
+// created in, else the one of its region annotation. It returns an empty
+// string when the Region is unknown.
+func regionOfBackup(backup *v1alpha1.Backup) string {
+	if md := backup.Status.ACKResourceMetadata; md != nil && md.Region != nil {
+		return string(*md.Region)
+	}
+	return backup.GetAnnotations()[ackv1alpha1.AnnotationRegion]
+}
+
+// referencesTable returns true if a replica of the supplied global table
+// references the supplied table when byRef is true, or is in its Region when
+// the global table is named after the DynamoDB table with the supplied name.
+func referencesTable(globalTable *v1alpha1.GlobalTable, ko *v1alpha1.Table, tableName, region string, byRef bool) bool {
+	for _, replica := range globalTable.Spec.ReplicationGroup {
+		if replica == nil {
+			continue
+		}
+		if ref := replica.TableRef; byRef && ref != nil && ref.From != nil && aws.StringValue(ref.From.Name) == ko.Name {
+			return true
+		}
+		if aws.StringValue(globalTable.Spec.GlobalTableName) != tableName {
+			continue
+		}
+		if region == "" || aws.StringValue(replica.RegionName) == region {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package dependents
+
+import (
+	"context"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+func TestOfTable(t *testing.T) {
+	region := ackv1alpha1.AWSRegion("us-west-2")
+	table := &v1alpha1.Table{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "orders"},
+		Spec:       v1alpha1.TableSpec{TableName: aws.String("orders-table")},
+	}
+	table.Status.ACKResourceMetadata = &ackv1alpha1.ResourceMetadata{Region: &region}
+
+	now := metav1.Now()
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&v1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "nightly"},
+			Spec:       v1alpha1.BackupSpec{TableName: aws.String("orders-table")},
+		},
+		&v1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "nightly"},
+			Spec:       v1alpha1.BackupSpec{TableName: aws.String("orders-table")},
+		},
+		&v1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "ns",
+				Name:        "annotated",
+				Annotations: map[string]string{ackv1alpha1.AnnotationRegion: "us-west-2"},
+			},
+			Spec: v1alpha1.BackupSpec{TableName: aws.String("orders-table")},
+		},
+		&v1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "ns",
+				Name:        "other-region",
+				Annotations: map[string]string{ackv1alpha1.AnnotationRegion: "eu-west-1"},
+			},
+			Spec: v1alpha1.BackupSpec{TableName: aws.String("orders-table")},
+		},
+		&v1alpha1.GlobalTable{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "by-region"},
+			Spec: v1alpha1.GlobalTableSpec{
+				GlobalTableName:  aws.String("orders-table"),
+				ReplicationGroup: []*v1alpha1.Replica{{RegionName: aws.String("us-west-2")}},
+			},
+		},
+		&v1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "ns",
+				Name:              "deleted",
+				DeletionTimestamp: &now,
+				Finalizers:        []string{"finalizers.dynamodb.services.k8s.aws/Backup"},
+			},
+			Spec: v1alpha1.BackupSpec{TableName: aws.String("orders-table")},
+		},
+		&v1alpha1.GlobalTable{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "other-region"},
+			Spec: v1alpha1.GlobalTableSpec{
+				GlobalTableName:  aws.String("orders-table"),
+				ReplicationGroup: []*v1alpha1.Replica{{RegionName: aws.String("eu-west-1")}},
+			},
+		},
+		&v1alpha1.GlobalTable{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "by-ref"},
+			Spec: v1alpha1.GlobalTableSpec{
+				GlobalTableName: aws.String("renamed"),
+				ReplicationGroup: []*v1alpha1.Replica{{TableRef: &ackv1alpha1.AWSResourceReferenceWrapper{
+					From: &ackv1alpha1.AWSResourceReference{Name: aws.String("orders")},
+				}}},
+			},
+		},
+	).Build()
+
+	_, err := OfTable(context.Background(), table)
+	require.Error(t, err)
+
+	deps, err := OfTable(NewContext(context.Background(), reader), table)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{
+		"Backup/nightly", "Backup/annotated", "GlobalTable/by-region", "GlobalTable/by-ref",
+	}, deps)
+
+	// Once replaced, the table is referenced by the name of its new table,
+	// the resources naming the old table reference the replaced table.
+	table.Status.PhysicalTableName = aws.String("orders-table-v2")
+	deps, err = OfTable(NewContext(context.Background(), reader), table)
+	require.NoError(t, err)
+	require.Equal(t, []string{"GlobalTable/by-ref"}, deps)
+
+	deps, err = OfReplacedTable(NewContext(context.Background(), reader), table, "orders-table")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{
+		"Backup/nightly", "Backup/annotated", "GlobalTable/by-region",
+	}, deps)
+}
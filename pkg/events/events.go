@@ -0,0 +1,44 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package events records Kubernetes events about the operations the
+// controller makes on DynamoDB resources.
+package events
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// recorderKey is the key of the event recorder in the contexts.
+type recorderKey struct{}
+
+// NewContext returns a copy of the supplied context carrying the recorder of
+// the events.
+func NewContext(ctx context.Context, r record.EventRecorder) context.Context {
+	return context.WithValue(ctx, recorderKey{}, r)
+}
+
+// Normal records an event of type Normal about the supplied object with the
+// recorder carried by the supplied context. Events are dropped when the
+// context carries no recorder.
+func Normal(ctx context.Context, obj runtime.Object, reason, messageFmt string, args ...interface{}) {
+	r, _ := ctx.Value(recorderKey{}).(record.EventRecorder)
+	if r == nil {
+		return
+	}
+	r.Eventf(obj, corev1.EventTypeNormal, reason, messageFmt, args...)
+}
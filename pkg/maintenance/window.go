@@ -0,0 +1,113 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package maintenance evaluates the maintenance windows in which the
+// controller makes the changes that put load on DynamoDB resources.
+package maintenance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/cron"
+)
+
+// DefaultDuration is the duration of the maintenance windows that don't
+// specify one.
+const DefaultDuration = time.Hour
+
+// Window is a parsed maintenance window.
+type Window struct {
+	schedule *cron.Schedule
+	duration time.Duration
+	location *time.Location
+}
+
+// NewWindow parses the supplied maintenance window.
+func NewWindow(mw *v1alpha1.MaintenanceWindow) (*Window, error) {
+	schedule, err := cron.Parse(aws.StringValue(mw.Schedule))
+	if err != nil {
+		return nil, err
+	}
+	if schedule.Next(time.Now()).IsZero() {
+		return nil, fmt.Errorf("schedule %q never activates", *mw.Schedule)
+	}
+	w := &Window{
+		schedule: schedule,
+		duration: DefaultDuration,
+		location: time.UTC,
+	}
+	if mw.Duration != nil {
+		if w.duration, err = time.ParseDuration(*mw.Duration); err != nil {
+			return nil, fmt.Errorf("invalid duration %q: %v", *mw.Duration, err)
+		}
+		if w.duration <= 0 {
+			return nil, fmt.Errorf("invalid duration %q: must be positive", *mw.Duration)
+		}
+	}
+	if mw.TimeZone != nil {
+		if w.location, err = time.LoadLocation(*mw.TimeZone); err != nil {
+			return nil, fmt.Errorf("invalid time zone %q: %v", *mw.TimeZone, err)
+		}
+	}
+	return w, nil
+}
+
+// IsOpen returns true if the window is open at the supplied time.
+func (w *Window) IsOpen(now time.Time) bool {
+	// The window is open if it opened in the last duration.
+	opening := w.schedule.Next(now.In(w.location).Add(-w.duration))
+	return !opening.IsZero() && !opening.After(now)
+}
+
+// NextOpening returns the next time the window opens after the supplied
+// time, or the zero time if it never opens again.
+func (w *Window) NextOpening(now time.Time) time.Time {
+	return w.schedule.Next(now.In(w.location))
+}
+
+// NamespaceDefault returns the maintenance window set with the
+// maintenance-window annotation of the supplied namespace, read with the
+// supplied reader, or nil if the namespace has none or doesn't exist.
+func NamespaceDefault(
+	ctx context.Context,
+	reader client.Reader,
+	namespace string,
+) (*v1alpha1.MaintenanceWindow, error) {
+	if namespace == "" {
+		return nil, nil
+	}
+	var ns corev1.Namespace
+	if err := reader.Get(ctx, client.ObjectKey{Name: namespace}, &ns); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	value, ok := ns.GetAnnotations()[v1alpha1.MaintenanceWindowAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	var mw v1alpha1.MaintenanceWindow
+	if err := json.Unmarshal([]byte(value), &mw); err != nil {
+		return nil, fmt.Errorf(
+			"invalid %s annotation on namespace %s: %v",
+			v1alpha1.MaintenanceWindowAnnotation, namespace, err,
+		)
+	}
+	return &mw, nil
+}
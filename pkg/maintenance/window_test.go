@@ -0,0 +1,53 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package maintenance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+func Test_Window(t *testing.T) {
+	w, err := NewWindow(&v1alpha1.MaintenanceWindow{
+		Schedule: aws.String("0 2 * * SAT"),
+		Duration: aws.String("3h"),
+		TimeZone: aws.String("Europe/Paris"),
+	})
+	require.NoError(t, err)
+	paris, _ := time.LoadLocation("Europe/Paris")
+
+	require.False(t, w.IsOpen(time.Date(2023, time.March, 4, 1, 59, 0, 0, paris)))
+	require.True(t, w.IsOpen(time.Date(2023, time.March, 4, 2, 0, 0, 0, paris)))
+	require.True(t, w.IsOpen(time.Date(2023, time.March, 4, 4, 59, 0, 0, paris)))
+	require.False(t, w.IsOpen(time.Date(2023, time.March, 4, 5, 0, 0, 0, paris)))
+
+	next := w.NextOpening(time.Date(2023, time.March, 4, 5, 0, 0, 0, time.UTC))
+	require.True(t, next.Equal(time.Date(2023, time.March, 11, 1, 0, 0, 0, time.UTC)))
+
+	_, err = NewWindow(&v1alpha1.MaintenanceWindow{Schedule: aws.String("0 0 31 2 *")})
+	require.Error(t, err)
+	_, err = NewWindow(&v1alpha1.MaintenanceWindow{
+		Schedule: aws.String("0 2 * * *"), Duration: aws.String("-1h"),
+	})
+	require.Error(t, err)
+	_, err = NewWindow(&v1alpha1.MaintenanceWindow{
+		Schedule: aws.String("0 2 * * *"), TimeZone: aws.String("Mars/Olympus"),
+	})
+	require.Error(t, err)
+}
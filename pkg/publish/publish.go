@@ -0,0 +1,69 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package publish publishes the names of DynamoDB resources to the
+// applications using them, through ConfigMaps.
+package publish
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TableNameKey is the key of the ConfigMaps data set to the name of a table.
+const TableNameKey = "tableName"
+
+// clientKey is the key of the Kubernetes client in the contexts.
+type clientKey struct{}
+
+// NewContext returns a copy of the supplied context carrying the client
+// writing the ConfigMaps.
+func NewContext(ctx context.Context, c client.Client) context.Context {
+	return context.WithValue(ctx, clientKey{}, c)
+}
+
+// TableName sets the tableName key of the supplied ConfigMap to the supplied
+// table name, creating the ConfigMap if it doesn't exist. The ConfigMap is
+// written with the client carried by the supplied context.
+func TableName(ctx context.Context, namespace, name, tableName string) error {
+	c, _ := ctx.Value(clientKey{}).(client.Client)
+	if c == nil {
+		return fmt.Errorf("cannot publish table name to ConfigMap %s/%s: no Kubernetes client", namespace, name)
+	}
+
+	var cm corev1.ConfigMap
+	err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &cm)
+	if apierrors.IsNotFound(err) {
+		return c.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Data:       map[string]string{TableNameKey: tableName},
+		})
+	}
+	if err != nil {
+		return err
+	}
+	if cm.Data[TableNameKey] == tableName {
+		return nil
+	}
+	patch := client.MergeFrom(cm.DeepCopy())
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[TableNameKey] = tableName
+	return c.Patch(ctx, &cm, patch)
+}
@@ -15,10 +15,12 @@ package backup
 
 import (
 	"errors"
+	"fmt"
 
 	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
 
 	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/throttle"
 )
 
 var (
@@ -58,3 +60,10 @@ func isBackupCreating(r *resource) bool {
 	dbis := *r.ko.Status.BackupStatus
 	return dbis == string(v1alpha1.BackupStatus_SDK_CREATING)
 }
+
+// requeueOnThrottle turns throttling and quota errors returned by the DynamoDB
+// API into a requeue with a jittered exponential delay.
+func requeueOnThrottle(r *resource, err error) error {
+	key := fmt.Sprintf("backup/%s/%s", r.ko.Namespace, r.ko.Name)
+	return throttle.RequeueOnThrottle(key, err)
+}
@@ -39,6 +39,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	svcapitypes "github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/throttle"
 )
 
 var (
@@ -306,6 +307,8 @@ func newResourceManager(
 	id ackv1alpha1.AWSAccountID,
 	region ackv1alpha1.AWSRegion,
 ) (*resourceManager, error) {
+	sdkapi := svcsdk.New(sess)
+	throttle.Instrument(sdkapi.Client)
 	return &resourceManager{
 		cfg:          cfg,
 		log:          log,
@@ -314,7 +317,7 @@ func newResourceManager(
 		awsAccountID: id,
 		awsRegion:    region,
 		sess:         sess,
-		sdkapi:       svcsdk.New(sess),
+		sdkapi:       sdkapi,
 	}, nil
 }
 
@@ -0,0 +1,110 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package backup
+
+import (
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlrtmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+var (
+	backupLabels = []string{"namespace", "name", "table_name", "backup_name"}
+
+	backupAgeDesc = prometheus.NewDesc(
+		"ack_dynamodb_backup_age_seconds",
+		"Time elapsed since the creation of a managed backup.",
+		backupLabels, nil,
+	)
+	backupSizeBytesDesc = prometheus.NewDesc(
+		"ack_dynamodb_backup_size_bytes",
+		"Size of a managed backup in bytes.",
+		backupLabels, nil,
+	)
+)
+
+// backupMetrics exposes the age and size of the managed backups. The age of a
+// backup is computed when the metrics are collected, so that it doesn't
+// depend on how often backups are reconciled.
+var backupMetrics = &backupCollector{
+	backups: map[string]*v1alpha1.Backup{},
+}
+
+func init() {
+	ctrlrtmetrics.Registry.MustRegister(backupMetrics)
+}
+
+// backupCollector is a prometheus.Collector reporting metrics about the last
+// observed state of the managed backups.
+type backupCollector struct {
+	sync.RWMutex
+	// backups maps the namespaced name of Backup CRs to their last observed
+	// state.
+	backups map[string]*v1alpha1.Backup
+}
+
+// Describe implements prometheus.Collector
+func (c *backupCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- backupAgeDesc
+	ch <- backupSizeBytesDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *backupCollector) Collect(ch chan<- prometheus.Metric) {
+	c.RLock()
+	defer c.RUnlock()
+
+	now := time.Now()
+	for _, ko := range c.backups {
+		labels := []string{
+			ko.Namespace,
+			ko.Name,
+			aws.StringValue(ko.Spec.TableName),
+			aws.StringValue(ko.Spec.BackupName),
+		}
+		if ko.Status.BackupCreationDateTime != nil {
+			ch <- prometheus.MustNewConstMetric(
+				backupAgeDesc, prometheus.GaugeValue,
+				now.Sub(ko.Status.BackupCreationDateTime.Time).Seconds(),
+				labels...,
+			)
+		}
+		if ko.Status.BackupSizeBytes != nil {
+			ch <- prometheus.MustNewConstMetric(
+				backupSizeBytesDesc, prometheus.GaugeValue,
+				float64(*ko.Status.BackupSizeBytes),
+				labels...,
+			)
+		}
+	}
+}
+
+// observe records the latest observed state of a backup.
+func (c *backupCollector) observe(ko *v1alpha1.Backup) {
+	c.Lock()
+	defer c.Unlock()
+	c.backups[ko.Namespace+"/"+ko.Name] = ko.DeepCopy()
+}
+
+// forget stops reporting metrics about a backup.
+func (c *backupCollector) forget(ko *v1alpha1.Backup) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.backups, ko.Namespace+"/"+ko.Name)
+}
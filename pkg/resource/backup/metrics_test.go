@@ -0,0 +1,82 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package backup
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+func newBackup(name string, sizeBytes int64) *v1alpha1.Backup {
+	ko := &v1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name},
+		Spec: v1alpha1.BackupSpec{
+			TableName:  aws.String("orders"),
+			BackupName: aws.String(name + "-backup"),
+		},
+	}
+	ko.Status.BackupSizeBytes = aws.Int64(sizeBytes)
+	return ko
+}
+
+func Test_backupCollector(t *testing.T) {
+	c := &backupCollector{backups: map[string]*v1alpha1.Backup{}}
+	registry := prometheus.NewPedanticRegistry()
+	require.NoError(t, registry.Register(c))
+
+	nightly := newBackup("nightly", 2048)
+	created := metav1.NewTime(time.Now().Add(-time.Hour))
+	nightly.Status.BackupCreationDateTime = &created
+	c.observe(nightly)
+	c.observe(newBackup("weekly", 4096))
+
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP ack_dynamodb_backup_size_bytes Size of a managed backup in bytes.
+# TYPE ack_dynamodb_backup_size_bytes gauge
+ack_dynamodb_backup_size_bytes{backup_name="nightly-backup",name="nightly",namespace="ns",table_name="orders"} 2048
+ack_dynamodb_backup_size_bytes{backup_name="weekly-backup",name="weekly",namespace="ns",table_name="orders"} 4096
+`), "ack_dynamodb_backup_size_bytes"))
+
+	// The age is computed on collection, and only for created backups.
+	mfs, err := registry.Gather()
+	require.NoError(t, err)
+	for _, mf := range mfs {
+		if mf.GetName() != "ack_dynamodb_backup_age_seconds" {
+			continue
+		}
+		require.Len(t, mf.GetMetric(), 1)
+		require.GreaterOrEqual(t, mf.GetMetric()[0].GetGauge().GetValue(), time.Hour.Seconds())
+	}
+
+	// The observed state is copied.
+	nightly.Status.BackupSizeBytes = aws.Int64(1)
+	c.forget(newBackup("weekly", 0))
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP ack_dynamodb_backup_size_bytes Size of a managed backup in bytes.
+# TYPE ack_dynamodb_backup_size_bytes gauge
+ack_dynamodb_backup_size_bytes{backup_name="nightly-backup",name="nightly",namespace="ns",table_name="orders"} 2048
+`), "ack_dynamodb_backup_size_bytes"))
+	count, err := testutil.GatherAndCount(registry)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+}
@@ -75,6 +75,7 @@ func (rm *resourceManager) sdkFind(
 
 	var resp *svcsdk.DescribeBackupOutput
 	resp, err = rm.sdkapi.DescribeBackupWithContext(ctx, input)
+	err = requeueOnThrottle(r, err)
 	rm.metrics.RecordAPICall("READ_ONE", "DescribeBackup", err)
 	if err != nil {
 		if reqErr, ok := ackerr.AWSRequestFailure(err); ok && reqErr.StatusCode() == 404 {
@@ -129,6 +130,7 @@ func (rm *resourceManager) sdkFind(
 	}
 
 	rm.setStatusDefaults(ko)
+	backupMetrics.observe(ko)
 	if isBackupCreating(&resource{ko}) {
 		return &resource{ko}, requeueWaitWhileCreating
 	}
@@ -179,6 +181,7 @@ func (rm *resourceManager) sdkCreate(
 	var resp *svcsdk.CreateBackupOutput
 	_ = resp
 	resp, err = rm.sdkapi.CreateBackupWithContext(ctx, input)
+	err = requeueOnThrottle(desired, err)
 	rm.metrics.RecordAPICall("CREATE", "CreateBackup", err)
 	if err != nil {
 		return nil, err
@@ -268,6 +271,7 @@ func (rm *resourceManager) sdkDelete(
 	defer func() {
 		exit(err)
 	}()
+	backupMetrics.forget(r.ko)
 	input, err := rm.newDeleteRequestPayload(r)
 	if err != nil {
 		return nil, err
@@ -275,6 +279,7 @@ func (rm *resourceManager) sdkDelete(
 	var resp *svcsdk.DeleteBackupOutput
 	_ = resp
 	resp, err = rm.sdkapi.DeleteBackupWithContext(ctx, input)
+	err = requeueOnThrottle(r, err)
 	rm.metrics.RecordAPICall("DELETE", "DeleteBackup", err)
 	return nil, err
 }
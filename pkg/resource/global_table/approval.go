@@ -0,0 +1,71 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package global_table
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/approval"
+)
+
+var (
+	ErrReplicaRemovalNotApproved = fmt.Errorf("replica removal is waiting for approval")
+
+	requeueWaitReplicaRemovalApproval = ackrequeue.NeededAfter(
+		ErrReplicaRemovalNotApproved,
+		30*time.Second,
+	)
+)
+
+// holdReplicaRemoval returns the error requeuing the update of a global table
+// until the removal of its replicas in the supplied Regions is approved, when
+// deleting replicas is a destructive operation. The removal is approved by
+// setting the approved-plan annotation to the hash reported in the
+// PendingApproval condition of ko.
+func holdReplicaRemoval(
+	ko *v1alpha1.GlobalTable,
+	desired *resource,
+	removed []string,
+) error {
+	if len(removed) == 0 || !approval.IsDestructive(approval.ActionDeleteReplica) {
+		return nil
+	}
+	var ops []*v1alpha1.PlannedOperation
+	for _, region := range removed {
+		ops = append(ops, approval.NewPlannedOperation(
+			"UpdateGlobalTable", approval.ActionDeleteReplica,
+			"delete replica in "+region,
+		))
+	}
+	hash := approval.PlanHash(ops, desired.ko.Generation)
+	if desired.ko.GetAnnotations()[v1alpha1.ApprovedPlanAnnotation] == hash {
+		msg := fmt.Sprintf("plan %s is approved", hash)
+		setConditionOfType(&resource{ko}, approval.ConditionTypePendingApproval, corev1.ConditionFalse, &msg, nil)
+		return nil
+	}
+	msg := fmt.Sprintf(
+		"deleting the replicas in %s is waiting for the %s annotation to be set to %q",
+		strings.Join(removed, ", "), v1alpha1.ApprovedPlanAnnotation, hash,
+	)
+	setConditionOfType(&resource{ko}, approval.ConditionTypePendingApproval, corev1.ConditionTrue, &msg, nil)
+	setConditionOfType(&resource{ko}, ackv1alpha1.ConditionTypeResourceSynced, corev1.ConditionFalse, &msg, nil)
+	return requeueWaitReplicaRemovalApproval
+}
@@ -0,0 +1,58 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package global_table
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/approval"
+)
+
+func Test_holdReplicaRemoval(t *testing.T) {
+	approval.Setup(approval.Config{DestructiveOperations: []string{approval.ActionDeleteReplica}})
+	defer approval.Setup(approval.Config{DestructiveOperations: approval.DefaultDestructiveActions})
+
+	desired := newGlobalTable([]string{"us-west-2"})
+	desired.ko.Generation = 2
+	require.NoError(t, holdReplicaRemoval(desired.ko.DeepCopy(), desired, nil))
+
+	ko := desired.ko.DeepCopy()
+	err := holdReplicaRemoval(ko, desired, []string{"eu-west-1"})
+	require.Equal(t, requeueWaitReplicaRemovalApproval, err)
+	cond := getConditionOfType(&resource{ko}, approval.ConditionTypePendingApproval)
+	require.Equal(t, corev1.ConditionTrue, cond.Status)
+	require.Contains(t, *cond.Message, "eu-west-1")
+
+	// The removal is approved with the hash reported in the condition.
+	hash := approval.PlanHash([]*v1alpha1.PlannedOperation{approval.NewPlannedOperation(
+		"UpdateGlobalTable", approval.ActionDeleteReplica, "delete replica in eu-west-1",
+	)}, 2)
+	require.Contains(t, *cond.Message, hash)
+	desired.ko.Annotations = map[string]string{v1alpha1.ApprovedPlanAnnotation: hash}
+	ko = desired.ko.DeepCopy()
+	require.NoError(t, holdReplicaRemoval(ko, desired, []string{"eu-west-1"}))
+	cond = getConditionOfType(&resource{ko}, approval.ConditionTypePendingApproval)
+	require.Equal(t, corev1.ConditionFalse, cond.Status)
+
+	// Approvals don't carry over to later generations.
+	desired.ko.Generation = 3
+	require.Error(t, holdReplicaRemoval(desired.ko.DeepCopy(), desired, []string{"eu-west-1"}))
+
+	approval.Setup(approval.Config{})
+	require.NoError(t, holdReplicaRemoval(desired.ko.DeepCopy(), desired, []string{"eu-west-1"}))
+}
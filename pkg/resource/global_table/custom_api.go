@@ -13,7 +13,13 @@
 
 package global_table
 
-import svcsdk "github.com/aws/aws-sdk-go/service/dynamodb"
+import (
+	"fmt"
+
+	svcsdk "github.com/aws/aws-sdk-go/service/dynamodb"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/throttle"
+)
 
 func customSetDeleteInput(r *resource, input *svcsdk.UpdateGlobalTableInput) {
 	for _, replica := range r.ko.Spec.ReplicationGroup {
@@ -25,3 +31,10 @@ func customSetDeleteInput(r *resource, input *svcsdk.UpdateGlobalTableInput) {
 		input.ReplicaUpdates = append(input.ReplicaUpdates, replicaUpdate)
 	}
 }
+
+// requeueOnThrottle turns throttling and quota errors returned by the DynamoDB
+// API into a requeue with a jittered exponential delay.
+func requeueOnThrottle(r *resource, err error) error {
+	key := fmt.Sprintf("globaltable/%s/%s", r.ko.Namespace, r.ko.Name)
+	return throttle.RequeueOnThrottle(key, err)
+}
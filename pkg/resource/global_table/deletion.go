@@ -0,0 +1,210 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package global_table
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go/aws"
+	svcsdk "github.com/aws/aws-sdk-go/service/dynamodb"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/events"
+)
+
+// Reasons of the events recorded when a global table is deleted.
+const (
+	EventReasonReplicasDetached     = "ReplicasDetached"
+	EventReasonReplicaTablesDeleted = "ReplicaTablesDeleted"
+)
+
+var (
+	ErrReplicasRemoving = fmt.Errorf("waiting for the replicas to be removed from the global table")
+
+	// The tables of the replicas of a global table can only be deleted once
+	// they are no longer replicas.
+	requeueWaitReplicasRemoved = ackrequeue.NeededAfter(
+		ErrReplicasRemoving,
+		requeueWaitReplicaTables,
+	)
+)
+
+// replicaDeletionMode returns the replica deletion mode of the supplied
+// global table, empty by default.
+func replicaDeletionMode(r *resource) v1alpha1.ReplicaDeletionMode {
+	if r.ko.Spec.ReplicaDeletionMode == nil {
+		return ""
+	}
+	return *r.ko.Spec.ReplicaDeletionMode
+}
+
+// isDetachMode returns true if the tables of the replicas of the supplied
+// global table are all kept when it's deleted.
+func isDetachMode(r *resource) bool {
+	return replicaDeletionMode(r) == v1alpha1.ReplicaDeletionMode_Detach
+}
+
+// tablesToDelete returns the sorted Regions of the tables deleted with the
+// supplied global table: the ones of its replicas and the ones created from
+// its template in DeleteTables mode, only the ones created from its template
+// by default.
+func tablesToDelete(r *resource) []*string {
+	if isDetachMode(r) {
+		return nil
+	}
+	regions := aws.StringValueSlice(r.ko.Status.TemplateTableRegions)
+	if replicaDeletionMode(r) == v1alpha1.ReplicaDeletionMode_DeleteTables {
+		regions = append(regions, replicaRegions(r)...)
+	}
+	seen := map[string]bool{}
+	var unique []string
+	for _, region := range regions {
+		if !seen[region] {
+			seen[region] = true
+			unique = append(unique, region)
+		}
+	}
+	sort.Strings(unique)
+	return aws.StringSlice(unique)
+}
+
+// isDeletingTables returns true if tables are deleted with the supplied
+// global table.
+func isDeletingTables(r *resource) bool {
+	return len(r.ko.Status.TablesPendingDeletion) > 0 || len(tablesToDelete(r)) > 0
+}
+
+// formatTables returns the names and Regions of the tables of a global table
+// in the supplied Regions.
+func formatTables(r *resource, regions []string) string {
+	tables := make([]string, len(regions))
+	for i, region := range regions {
+		tables[i] = fmt.Sprintf("%s (%s)", aws.StringValue(r.ko.Spec.GlobalTableName), region)
+	}
+	return strings.Join(tables, ", ")
+}
+
+// recordReplicasDetached records the event reporting that the replicas of
+// the supplied global table were removed and their tables kept.
+func recordReplicasDetached(ctx context.Context, r *resource) {
+	regions := replicaRegions(r)
+	if len(regions) == 0 {
+		return
+	}
+	events.Normal(
+		ctx, r.ko, EventReasonReplicasDetached,
+		"Removed the replicas from the global table, keeping their tables: %s",
+		formatTables(r, regions),
+	)
+}
+
+// deleteReplicaTables deletes a global table and the tables of its replicas,
+// per its replica deletion mode: the replicas are removed first, then the
+// tables recorded in Status.TablesPendingDeletion are deleted. It returns a
+// requeue error until every table is deleted.
+func (rm *resourceManager) deleteReplicaTables(
+	ctx context.Context,
+	r *resource,
+) (latest *resource, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.deleteReplicaTables")
+	defer func() { exit(err) }()
+	defer func() {
+		err = requeueOnThrottle(r, err)
+	}()
+
+	ko := r.ko.DeepCopy()
+	if ko.Status.TablesPendingDeletion == nil {
+		ko.Status.TablesPendingDeletion = tablesToDelete(r)
+	}
+	if len(ko.Spec.ReplicationGroup) > 0 {
+		input, err := rm.newDeleteRequestPayload(r)
+		if err != nil {
+			return nil, err
+		}
+		customSetDeleteInput(r, input)
+		_, err = rm.sdkapi.UpdateGlobalTableWithContext(ctx, input)
+		rm.metrics.RecordAPICall("DELETE", "UpdateGlobalTable", err)
+		if awsErr, ok := ackerr.AWSError(err); ok && awsErr.Code() == "ReplicaNotFoundException" {
+			err = nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &resource{ko}, requeueWaitReplicasRemoved
+	}
+
+	var deleted []string
+	var remaining []*string
+	for _, region := range ko.Status.TablesPendingDeletion {
+		_, err := rm.replicaAPIs.get(aws.StringValue(region)).DeleteTableWithContext(
+			ctx,
+			&svcsdk.DeleteTableInput{TableName: ko.Spec.GlobalTableName},
+		)
+		rm.metrics.RecordAPICall("DELETE", "DeleteTable", err)
+		if awsErr, ok := ackerr.AWSError(err); ok {
+			switch awsErr.Code() {
+			case "ResourceNotFoundException":
+				continue
+			case "ResourceInUseException":
+				remaining = append(remaining, region)
+				continue
+			}
+		}
+		if err != nil {
+			return &resource{ko}, fmt.Errorf("cannot delete table in %s: %v", aws.StringValue(region), err)
+		}
+		deleted = append(deleted, aws.StringValue(region))
+	}
+	if len(deleted) > 0 {
+		events.Normal(
+			ctx, ko, EventReasonReplicaTablesDeleted,
+			"Deleted the tables of the replicas removed from the global table: %s",
+			formatTables(r, deleted),
+		)
+	}
+	if len(remaining) > 0 {
+		ko.Status.TablesPendingDeletion = remaining
+		return &resource{ko}, ackrequeue.NeededAfter(
+			fmt.Errorf("waiting to delete regional tables in %s", strings.Join(aws.StringValueSlice(remaining), ", ")),
+			requeueWaitReplicaTables,
+		)
+	}
+	return nil, nil
+}
+
+// replicaTablesTeardown returns the global table to delete when the supplied
+// error reports it doesn't exist anymore while tables of its replicas remain
+// to delete. DynamoDB deletes global tables without replicas.
+func replicaTablesTeardown(r *resource, err error) (*resource, bool) {
+	if !r.IsBeingDeleted() {
+		return nil, false
+	}
+	if len(r.ko.Status.TablesPendingDeletion) == 0 &&
+		(isDetachMode(r) || len(r.ko.Status.TemplateTableRegions) == 0) {
+		return nil, false
+	}
+	if awsErr, ok := ackerr.AWSError(err); !ok || awsErr.Code() != "GlobalTableNotFoundException" {
+		return nil, false
+	}
+	ko := r.ko.DeepCopy()
+	ko.Spec.ReplicationGroup = nil
+	return &resource{ko}, true
+}
@@ -0,0 +1,159 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package global_table
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	svcsdk "github.com/aws/aws-sdk-go/service/dynamodb"
+	svcsdkapi "github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/events"
+)
+
+func setReplicaDeletionMode(r *resource, mode v1alpha1.ReplicaDeletionMode) {
+	r.ko.Spec.ReplicaDeletionMode = &mode
+}
+
+func Test_tablesToDelete(t *testing.T) {
+	r := newTemplateGlobalTable("us-west-2", "eu-west-1")
+	r.ko.Status.TemplateTableRegions = aws.StringSlice([]string{"eu-west-1"})
+
+	// Only the tables created from the template by default.
+	require.Equal(t, []string{"eu-west-1"}, aws.StringValueSlice(tablesToDelete(r)))
+	require.True(t, isDeletingTables(r))
+
+	setReplicaDeletionMode(r, v1alpha1.ReplicaDeletionMode_DeleteTables)
+	require.Equal(t, []string{"eu-west-1", "us-west-2"}, aws.StringValueSlice(tablesToDelete(r)))
+
+	setReplicaDeletionMode(r, v1alpha1.ReplicaDeletionMode_Detach)
+	require.Empty(t, tablesToDelete(r))
+	require.False(t, isDeletingTables(r))
+
+	// Tables already pending deletion are still deleted.
+	r.ko.Status.TablesPendingDeletion = aws.StringSlice([]string{"us-west-2"})
+	require.True(t, isDeletingTables(r))
+}
+
+func Test_deleteReplicaTables(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	ctx := events.NewContext(context.Background(), recorder)
+
+	us := &fakeDynamoDB{tableStatus: svcsdk.TableStatusActive}
+	eu := &fakeDynamoDB{tableStatus: svcsdk.TableStatusActive}
+	global := &fakeDynamoDB{}
+	rm := &resourceManager{
+		sdkapi:  global,
+		metrics: testMetrics,
+		replicaAPIs: &regionalAPIs{apis: map[string]svcsdkapi.DynamoDBAPI{
+			"us-west-2": us,
+			"eu-west-1": eu,
+		}},
+	}
+	r := newTemplateGlobalTable("us-west-2", "eu-west-1")
+	setReplicaDeletionMode(r, v1alpha1.ReplicaDeletionMode_DeleteTables)
+	r.ko.Status.TemplateTableRegions = aws.StringSlice([]string{"eu-west-1"})
+
+	// The replicas are removed first, and the tables to delete recorded.
+	latest, err := rm.deleteReplicaTables(ctx, r)
+	require.True(t, errors.Is(err, ErrReplicasRemoving))
+	require.NotNil(t, latest)
+	require.Equal(t, []string{"eu-west-1", "us-west-2"}, aws.StringValueSlice(latest.ko.Status.TablesPendingDeletion))
+	require.Len(t, global.updated.ReplicaUpdates, 2)
+	require.False(t, eu.deleted)
+
+	// Then the recorded tables.
+	latest.ko.Spec.ReplicationGroup = nil
+	latest, err = rm.deleteReplicaTables(ctx, latest)
+	require.NoError(t, err)
+	require.Nil(t, latest)
+	require.True(t, eu.deleted)
+	require.True(t, us.deleted)
+	require.Len(t, recorder.Events, 1)
+	require.Contains(t, <-recorder.Events, EventReasonReplicaTablesDeleted)
+}
+
+func Test_deleteReplicaTables_Default(t *testing.T) {
+	us := &fakeDynamoDB{tableStatus: svcsdk.TableStatusActive}
+	eu := &fakeDynamoDB{tableStatus: svcsdk.TableStatusActive}
+	rm := &resourceManager{
+		sdkapi:  &fakeDynamoDB{},
+		metrics: testMetrics,
+		replicaAPIs: &regionalAPIs{apis: map[string]svcsdkapi.DynamoDBAPI{
+			"us-west-2": us,
+			"eu-west-1": eu,
+		}},
+	}
+	r := newTemplateGlobalTable()
+	r.ko.Status.TemplateTableRegions = aws.StringSlice([]string{"eu-west-1"})
+
+	// Only the tables created from the template are deleted.
+	latest, err := rm.deleteReplicaTables(context.Background(), r)
+	require.NoError(t, err)
+	require.Nil(t, latest)
+	require.True(t, eu.deleted)
+	require.False(t, us.deleted)
+}
+
+func Test_recordReplicasDetached(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	ctx := events.NewContext(context.Background(), recorder)
+
+	r := newTemplateGlobalTable("us-west-2", "eu-west-1")
+	setReplicaDeletionMode(r, v1alpha1.ReplicaDeletionMode_Detach)
+	require.False(t, isDeletingTables(r))
+
+	recordReplicasDetached(ctx, r)
+	require.Len(t, recorder.Events, 1)
+	event := <-recorder.Events
+	require.Contains(t, event, EventReasonReplicasDetached)
+	require.Contains(t, event, "(eu-west-1)")
+	require.Contains(t, event, "(us-west-2)")
+}
+
+func Test_replicaTablesTeardown(t *testing.T) {
+	notFound := awserr.New("GlobalTableNotFoundException", "global table not found", nil)
+	r := newTemplateGlobalTable("us-west-2")
+	r.ko.Status.TemplateTableRegions = aws.StringSlice([]string{"us-west-2"})
+
+	_, ok := replicaTablesTeardown(r, notFound)
+	require.False(t, ok)
+
+	now := metav1.Now()
+	r.ko.DeletionTimestamp = &now
+	teardown, ok := replicaTablesTeardown(r, notFound)
+	require.True(t, ok)
+	require.Empty(t, teardown.ko.Spec.ReplicationGroup)
+
+	_, ok = replicaTablesTeardown(r, nil)
+	require.False(t, ok)
+
+	// The tables are kept in Detach mode.
+	setReplicaDeletionMode(r, v1alpha1.ReplicaDeletionMode_Detach)
+	_, ok = replicaTablesTeardown(r, notFound)
+	require.False(t, ok)
+
+	// Unless their deletion already started.
+	r.ko.Status.TablesPendingDeletion = aws.StringSlice([]string{"us-west-2"})
+	_, ok = replicaTablesTeardown(r, notFound)
+	require.True(t, ok)
+}
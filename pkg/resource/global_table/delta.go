@@ -42,6 +42,7 @@ func newResourceDelta(
 		delta.Add("", a, b)
 		return delta
 	}
+	customPreCompare(delta, a, b)
 
 	if ackcompare.HasNilDifference(a.ko.Spec.GlobalTableName, b.ko.Spec.GlobalTableName) {
 		delta.Add("Spec.GlobalTableName", a.ko.Spec.GlobalTableName, b.ko.Spec.GlobalTableName)
@@ -50,9 +51,6 @@ func newResourceDelta(
 			delta.Add("Spec.GlobalTableName", a.ko.Spec.GlobalTableName, b.ko.Spec.GlobalTableName)
 		}
 	}
-	if !reflect.DeepEqual(a.ko.Spec.ReplicationGroup, b.ko.Spec.ReplicationGroup) {
-		delta.Add("Spec.ReplicationGroup", a.ko.Spec.ReplicationGroup, b.ko.Spec.ReplicationGroup)
-	}
 
 	return delta
 }
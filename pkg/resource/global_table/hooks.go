@@ -0,0 +1,319 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package global_table
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/aws/session"
+	svcsdk "github.com/aws/aws-sdk-go/service/dynamodb"
+	svcsdkapi "github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/tags"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/throttle"
+)
+
+// regionalAPIs caches the DynamoDB APIs of the Regions of the replica tables
+// of global tables.
+type regionalAPIs struct {
+	sess *session.Session
+
+	mu   sync.Mutex
+	apis map[string]svcsdkapi.DynamoDBAPI
+}
+
+// newRegionalAPIs returns the regional DynamoDB APIs created from the
+// supplied session.
+func newRegionalAPIs(sess *session.Session) *regionalAPIs {
+	return &regionalAPIs{
+		sess: sess,
+		apis: map[string]svcsdkapi.DynamoDBAPI{},
+	}
+}
+
+// get returns the DynamoDB API of the supplied Region.
+func (c *regionalAPIs) get(region string) svcsdkapi.DynamoDBAPI {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if api, ok := c.apis[region]; ok {
+		return api
+	}
+	client := svcsdk.New(c.sess, aws.NewConfig().WithRegion(region))
+	throttle.Instrument(client.Client)
+	c.apis[region] = client
+	return client
+}
+
+// replicaRegions returns the Regions of the replica tables of the supplied
+// global table, sorted.
+func replicaRegions(r *resource) []string {
+	var regions []string
+	for _, replica := range r.ko.Spec.ReplicationGroup {
+		if replica != nil && replica.RegionName != nil {
+			regions = append(regions, *replica.RegionName)
+		}
+	}
+	sort.Strings(regions)
+	return regions
+}
+
+// replicaTableARN returns the ARN of the replica table of the supplied global
+// table in the supplied Region.
+func (rm *resourceManager) replicaTableARN(r *resource, region string) string {
+	partition := endpoints.AwsPartitionID
+	if p, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region); ok {
+		partition = p.ID()
+	}
+	return fmt.Sprintf(
+		"arn:%s:dynamodb:%s:%s:table/%s",
+		partition, region, rm.awsAccountID, aws.StringValue(r.ko.Spec.GlobalTableName),
+	)
+}
+
+// customPreCompare compares the Regions of the replicas and the tags of two
+// global tables regardless of their order.
+func customPreCompare(
+	delta *ackcompare.Delta,
+	a *resource,
+	b *resource,
+) {
+	if !equalStringSets(replicaRegions(a), replicaRegions(b)) {
+		delta.Add("Spec.ReplicationGroup", a.ko.Spec.ReplicationGroup, b.ko.Spec.ReplicationGroup)
+	}
+	if !tags.Equal(a.ko.Spec.Tags, b.ko.Spec.Tags) {
+		delta.Add("Spec.Tags", a.ko.Spec.Tags, b.ko.Spec.Tags)
+	}
+}
+
+// customUpdateGlobalTable adds and removes the replicas of a global table and
+// syncs the tags of its replica tables. The tables of the added Regions are
+// created from the table template first.
+func (rm *resourceManager) customUpdateGlobalTable(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+	delta *ackcompare.Delta,
+) (updated *resource, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.customUpdateGlobalTable")
+	defer func() { exit(err) }()
+	defer func() {
+		err = requeueOnThrottle(desired, err)
+	}()
+
+	ko := desired.ko.DeepCopy()
+	latest.ko.Status.DeepCopyInto(&ko.Status)
+	rm.setStatusDefaults(ko)
+	updated = &resource{ko}
+
+	if delta.DifferentAt("Spec.ReplicationGroup") {
+		// The tables of the new Regions are created from the template first.
+		if updated, err = rm.ensureTemplateTables(ctx, updated); err != nil {
+			return updated, err
+		}
+		if err := rm.syncReplicationGroup(ctx, updated.ko, desired, latest); err != nil {
+			return updated, err
+		}
+	}
+	// The tables of new replicas are tagged as well.
+	if delta.DifferentAt("Spec.Tags") || delta.DifferentAt("Spec.ReplicationGroup") {
+		if err := rm.syncReplicaTags(ctx, desired); err != nil {
+			return nil, err
+		}
+	}
+	return updated, nil
+}
+
+// syncReplicationGroup creates the replicas of the Regions added to a global
+// table and deletes the ones of the removed Regions, once their deletion is
+// approved. The approval is reported in the conditions of ko.
+func (rm *resourceManager) syncReplicationGroup(
+	ctx context.Context,
+	ko *v1alpha1.GlobalTable,
+	desired *resource,
+	latest *resource,
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.syncReplicationGroup")
+	defer func() { exit(err) }()
+
+	added, removed := computeStringSetDelta(replicaRegions(desired), replicaRegions(latest))
+	if err := holdReplicaRemoval(ko, desired, removed); err != nil {
+		return err
+	}
+	input := &svcsdk.UpdateGlobalTableInput{
+		GlobalTableName: desired.ko.Spec.GlobalTableName,
+	}
+	for _, region := range added {
+		input.ReplicaUpdates = append(input.ReplicaUpdates, &svcsdk.ReplicaUpdate{
+			Create: &svcsdk.CreateReplicaAction{RegionName: aws.String(region)},
+		})
+	}
+	for _, region := range removed {
+		input.ReplicaUpdates = append(input.ReplicaUpdates, &svcsdk.ReplicaUpdate{
+			Delete: &svcsdk.DeleteReplicaAction{RegionName: aws.String(region)},
+		})
+	}
+	if len(input.ReplicaUpdates) == 0 {
+		return nil
+	}
+	_, err = rm.sdkapi.UpdateGlobalTableWithContext(ctx, input)
+	rm.metrics.RecordAPICall("UPDATE", "UpdateGlobalTable", err)
+	return err
+}
+
+// syncReplicaTags adds, updates and removes the tags of every replica table
+// of a global table so they match the desired tags. The replica tables not
+// created yet are skipped.
+func (rm *resourceManager) syncReplicaTags(
+	ctx context.Context,
+	desired *resource,
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.syncReplicaTags")
+	defer func() { exit(err) }()
+
+	for _, region := range replicaRegions(desired) {
+		api := rm.replicaAPIs.get(region)
+		arn := rm.replicaTableARN(desired, region)
+		replicaTags, found, err := rm.getReplicaTags(ctx, api, arn)
+		if err != nil {
+			return fmt.Errorf("cannot read tags of replica table in %s: %v", region, err)
+		}
+		if !found {
+			continue
+		}
+		added, removed := tags.ComputeDelta(desired.ko.Spec.Tags, replicaTags)
+		if len(removed) > 0 {
+			_, err = api.UntagResourceWithContext(ctx, &svcsdk.UntagResourceInput{
+				ResourceArn: aws.String(arn),
+				TagKeys:     removed,
+			})
+			rm.metrics.RecordAPICall("UPDATE", "UntagResource", err)
+			if err != nil {
+				return err
+			}
+		}
+		if len(added) > 0 {
+			_, err = api.TagResourceWithContext(ctx, &svcsdk.TagResourceInput{
+				ResourceArn: aws.String(arn),
+				Tags:        tags.ToSDK(added),
+			})
+			rm.metrics.RecordAPICall("UPDATE", "TagResource", err)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// setReplicaTags sets the tags of the latest state of a global table: the
+// desired tags when every replica table has them, otherwise the tags of the
+// first replica table that differs.
+func (rm *resourceManager) setReplicaTags(
+	ctx context.Context,
+	desired *resource,
+	ko *v1alpha1.GlobalTable,
+) error {
+	ko.Spec.Tags = desired.ko.Spec.Tags
+	for _, region := range replicaRegions(&resource{ko}) {
+		arn := rm.replicaTableARN(&resource{ko}, region)
+		replicaTags, found, err := rm.getReplicaTags(ctx, rm.replicaAPIs.get(region), arn)
+		if err != nil {
+			return requeueOnThrottle(desired, err)
+		}
+		if found && !tags.Equal(desired.ko.Spec.Tags, replicaTags) {
+			ko.Spec.Tags = replicaTags
+			return nil
+		}
+	}
+	return nil
+}
+
+// getReplicaTags returns the tags of the replica table with the supplied ARN,
+// without the tags assigned by AWS. It returns false if the table doesn't
+// exist.
+func (rm *resourceManager) getReplicaTags(
+	ctx context.Context,
+	api svcsdkapi.DynamoDBAPI,
+	arn string,
+) ([]*v1alpha1.Tag, bool, error) {
+	replicaTags := []*v1alpha1.Tag{}
+	var token *string
+	for {
+		resp, err := api.ListTagsOfResourceWithContext(
+			ctx,
+			&svcsdk.ListTagsOfResourceInput{
+				NextToken:   token,
+				ResourceArn: aws.String(arn),
+			},
+		)
+		rm.metrics.RecordAPICall("GET", "ListTagsOfResource", err)
+		if awsErr, ok := ackerr.AWSError(err); ok && awsErr.Code() == "ResourceNotFoundException" {
+			return nil, false, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		for _, tag := range resp.Tags {
+			if !tags.IsAWSTag(aws.StringValue(tag.Key)) {
+				replicaTags = append(replicaTags, &v1alpha1.Tag{Key: tag.Key, Value: tag.Value})
+			}
+		}
+		if resp.NextToken == nil {
+			break
+		}
+		token = resp.NextToken
+	}
+	return replicaTags, true, nil
+}
+
+// computeStringSetDelta returns the elements of desired missing from latest
+// and the elements of latest missing from desired.
+func computeStringSetDelta(desired, latest []string) (added, removed []string) {
+	latestSet := map[string]bool{}
+	for _, s := range latest {
+		latestSet[s] = true
+	}
+	desiredSet := map[string]bool{}
+	for _, s := range desired {
+		desiredSet[s] = true
+		if !latestSet[s] {
+			added = append(added, s)
+		}
+	}
+	for _, s := range latest {
+		if !desiredSet[s] {
+			removed = append(removed, s)
+		}
+	}
+	return added, removed
+}
+
+// equalStringSets returns true if two string arrays have the same elements
+// regardless of their order.
+func equalStringSets(a, b []string) bool {
+	added, removed := computeStringSetDelta(a, b)
+	return len(added) == 0 && len(removed) == 0
+}
@@ -0,0 +1,82 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package global_table
+
+import (
+	"testing"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+var (
+	Tag1 = &v1alpha1.Tag{
+		Key:   aws.String("k1"),
+		Value: aws.String("v1"),
+	}
+	Tag2 = &v1alpha1.Tag{
+		Key:   aws.String("k2"),
+		Value: aws.String("v2"),
+	}
+	Tag2Updated = &v1alpha1.Tag{
+		Key:   aws.String("k2"),
+		Value: aws.String("v2-updated"),
+	}
+)
+
+func newGlobalTable(regions []string, tags ...*v1alpha1.Tag) *resource {
+	ko := &v1alpha1.GlobalTable{Spec: v1alpha1.GlobalTableSpec{
+		GlobalTableName: aws.String("orders"),
+		Tags:            tags,
+	}}
+	for _, region := range regions {
+		ko.Spec.ReplicationGroup = append(ko.Spec.ReplicationGroup, &v1alpha1.Replica{
+			RegionName: aws.String(region),
+		})
+	}
+	return &resource{ko}
+}
+
+func Test_customPreCompare(t *testing.T) {
+	a := newGlobalTable([]string{"us-west-2", "eu-west-1"}, Tag1, Tag2)
+	b := newGlobalTable([]string{"eu-west-1", "us-west-2"}, Tag2, Tag1)
+	delta := ackcompare.NewDelta()
+	customPreCompare(delta, a, b)
+	require.Empty(t, delta.Differences)
+
+	b = newGlobalTable([]string{"eu-west-1"}, Tag2Updated)
+	delta = ackcompare.NewDelta()
+	customPreCompare(delta, a, b)
+	require.True(t, delta.DifferentAt("Spec.ReplicationGroup"))
+	require.True(t, delta.DifferentAt("Spec.Tags"))
+}
+
+func Test_computeStringSetDelta(t *testing.T) {
+	added, removed := computeStringSetDelta(
+		[]string{"us-west-2", "eu-west-1"},
+		[]string{"us-west-2", "ap-south-1"},
+	)
+	require.Equal(t, []string{"eu-west-1"}, added)
+	require.Equal(t, []string{"ap-south-1"}, removed)
+}
+
+func Test_replicaTableARN(t *testing.T) {
+	rm := &resourceManager{awsAccountID: "123456789012"}
+	r := newGlobalTable([]string{"us-west-2"})
+	require.Equal(t, "arn:aws:dynamodb:us-west-2:123456789012:table/orders", rm.replicaTableARN(r, "us-west-2"))
+	require.Equal(t, "arn:aws-cn:dynamodb:cn-north-1:123456789012:table/orders", rm.replicaTableARN(r, "cn-north-1"))
+}
@@ -39,6 +39,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	svcapitypes "github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/throttle"
 )
 
 var (
@@ -79,6 +80,9 @@ type resourceManager struct {
 	// sdk is a pointer to the AWS service API interface exposed by the
 	// aws-sdk-go/services/{alias}/{alias}iface package.
 	sdkapi svcsdkapi.DynamoDBAPI
+	// replicaAPIs are the DynamoDB APIs of the Regions of the replica
+	// tables.
+	replicaAPIs *regionalAPIs
 }
 
 // concreteResource returns a pointer to a resource from the supplied
@@ -291,7 +295,17 @@ func (rm *resourceManager) EnsureTags(
 	res acktypes.AWSResource,
 	md acktypes.ServiceControllerMetadata,
 ) error {
-
+	r := rm.concreteResource(res)
+	if r.ko == nil {
+		// Should never happen... if it does, it's buggy code.
+		panic("resource manager's EnsureTags method received resource with nil CR object")
+	}
+	defaultTags := ackrt.GetDefaultTags(&rm.cfg, r.ko, md)
+	var existingTags []*svcapitypes.Tag
+	existingTags = r.ko.Spec.Tags
+	resourceTags := ToACKTags(existingTags)
+	tags := acktags.Merge(resourceTags, defaultTags)
+	r.ko.Spec.Tags = FromACKTags(tags)
 	return nil
 }
 
@@ -306,6 +320,8 @@ func newResourceManager(
 	id ackv1alpha1.AWSAccountID,
 	region ackv1alpha1.AWSRegion,
 ) (*resourceManager, error) {
+	sdkapi := svcsdk.New(sess)
+	throttle.Instrument(sdkapi.Client)
 	return &resourceManager{
 		cfg:          cfg,
 		log:          log,
@@ -314,7 +330,8 @@ func newResourceManager(
 		awsAccountID: id,
 		awsRegion:    region,
 		sess:         sess,
-		sdkapi:       svcsdk.New(sess),
+		sdkapi:       sdkapi,
+		replicaAPIs:  newRegionalAPIs(sess),
 	}, nil
 }
 
@@ -17,8 +17,14 @@ package global_table
 
 import (
 	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
 	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
 
 	svcapitypes "github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
@@ -31,6 +37,12 @@ import (
 func (rm *resourceManager) ClearResolvedReferences(res acktypes.AWSResource) acktypes.AWSResource {
 	ko := rm.concreteResource(res).ko.DeepCopy()
 
+	for f0idx, f0iter := range ko.Spec.ReplicationGroup {
+		if f0iter != nil && f0iter.TableRef != nil {
+			ko.Spec.ReplicationGroup[f0idx].RegionName = nil
+		}
+	}
+
 	return &resource{ko}
 }
 
@@ -46,11 +58,110 @@ func (rm *resourceManager) ResolveReferences(
 	apiReader client.Reader,
 	res acktypes.AWSResource,
 ) (acktypes.AWSResource, bool, error) {
-	return res, false, nil
+	namespace := res.MetaObject().GetNamespace()
+	ko := rm.concreteResource(res).ko.DeepCopy()
+
+	resourceHasReferences := false
+	err := validateReferenceFields(ko)
+	if fieldHasReferences, err := rm.resolveReferenceForReplicationGroup_RegionName(ctx, apiReader, namespace, ko); err != nil {
+		return &resource{ko}, (resourceHasReferences || fieldHasReferences), err
+	} else {
+		resourceHasReferences = resourceHasReferences || fieldHasReferences
+	}
+
+	// The referenced tables must be compatible before the global table is
+	// created from them.
+	if resourceHasReferences && err == nil && !isGlobalTableCreated(ko) {
+		err = checkReplicaTables(ctx, apiReader, namespace, ko)
+	}
+
+	return &resource{ko}, resourceHasReferences, err
 }
 
 // validateReferenceFields validates the reference field and corresponding
 // identifier field.
 func validateReferenceFields(ko *svcapitypes.GlobalTable) error {
+	for _, f0iter := range ko.Spec.ReplicationGroup {
+		if f0iter != nil && f0iter.TableRef != nil && f0iter.RegionName != nil {
+			return ackerr.ResourceReferenceAndIDNotSupportedFor("ReplicationGroup.RegionName", "ReplicationGroup.TableRef")
+		}
+	}
+	return nil
+}
+
+// resolveReferenceForReplicationGroup_RegionName reads the resource
+// reference, reads the referenced resource and sets the resource reference
+// into the spec.
+func (rm *resourceManager) resolveReferenceForReplicationGroup_RegionName(
+	ctx context.Context,
+	apiReader client.Reader,
+	namespace string,
+	ko *svcapitypes.GlobalTable,
+) (hasReferences bool, err error) {
+	for f0idx, f0iter := range ko.Spec.ReplicationGroup {
+		if f0iter != nil && f0iter.TableRef != nil && f0iter.TableRef.From != nil {
+			hasReferences = true
+			arr := f0iter.TableRef.From
+			if arr.Name == nil || *arr.Name == "" {
+				return hasReferences, fmt.Errorf("provided resource reference is nil or empty: ReplicationGroup.TableRef")
+			}
+			obj := &svcapitypes.Table{}
+			if err := getReferencedResourceState_Table(ctx, apiReader, obj, *arr.Name, namespace); err != nil {
+				return hasReferences, err
+			}
+			region := string(*obj.Status.ACKResourceMetadata.Region)
+			ko.Spec.ReplicationGroup[f0idx].RegionName = &region
+		}
+	}
+
+	return hasReferences, nil
+}
+
+// getReferencedResourceState_Table looks up whether a referenced resource
+// exists and is in a ACK.ResourceSynced=True state. If the referenced resource does exist and is
+// in a Synced state, returns nil, otherwise returns `ackerr.ResourceReferenceTerminalFor` or
+// `ResourceReferenceNotSyncedFor` depending on if the resource is in a Terminal state.
+func getReferencedResourceState_Table(
+	ctx context.Context,
+	apiReader client.Reader,
+	obj *svcapitypes.Table,
+	name string, // the Kubernetes name of the referenced resource
+	namespace string, // the Kubernetes namespace of the referenced resource
+) error {
+	namespacedName := types.NamespacedName{
+		Namespace: namespace,
+		Name:      name,
+	}
+	err := apiReader.Get(ctx, namespacedName, obj)
+	if err != nil {
+		return err
+	}
+	var refResourceSynced, refResourceTerminal bool
+	for _, cond := range obj.Status.Conditions {
+		if cond.Type == ackv1alpha1.ConditionTypeResourceSynced &&
+			cond.Status == corev1.ConditionTrue {
+			refResourceSynced = true
+		}
+		if cond.Type == ackv1alpha1.ConditionTypeTerminal &&
+			cond.Status == corev1.ConditionTrue {
+			refResourceTerminal = true
+		}
+	}
+	if refResourceTerminal {
+		return ackerr.ResourceReferenceTerminalFor(
+			"Table",
+			namespace, name)
+	}
+	if !refResourceSynced {
+		return ackerr.ResourceReferenceNotSyncedFor(
+			"Table",
+			namespace, name)
+	}
+	if obj.Status.ACKResourceMetadata == nil || obj.Status.ACKResourceMetadata.Region == nil {
+		return ackerr.ResourceReferenceMissingTargetFieldFor(
+			"Table",
+			namespace, name,
+			"Status.ACKResourceMetadata.Region")
+	}
 	return nil
 }
@@ -0,0 +1,148 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package global_table
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+func newTestTable(name, region, tableStatus string) *v1alpha1.Table {
+	awsRegion := ackv1alpha1.AWSRegion(region)
+	return &v1alpha1.Table{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: v1alpha1.TableSpec{
+			TableName: aws.String("orders"),
+			KeySchema: []*v1alpha1.KeySchemaElement{
+				{AttributeName: aws.String("id"), KeyType: aws.String("HASH")},
+			},
+			AttributeDefinitions: []*v1alpha1.AttributeDefinition{
+				{AttributeName: aws.String("id"), AttributeType: aws.String("S")},
+			},
+			StreamSpecification: &v1alpha1.StreamSpecification{
+				StreamEnabled:  aws.Bool(true),
+				StreamViewType: aws.String("NEW_AND_OLD_IMAGES"),
+			},
+		},
+		Status: v1alpha1.TableStatus{
+			ACKResourceMetadata: &ackv1alpha1.ResourceMetadata{Region: &awsRegion},
+			Conditions: []*ackv1alpha1.Condition{{
+				Type:   ackv1alpha1.ConditionTypeResourceSynced,
+				Status: corev1.ConditionTrue,
+			}},
+			TableStatus: aws.String(tableStatus),
+		},
+	}
+}
+
+func newTableReferencingGlobalTable(names ...string) *resource {
+	ko := &v1alpha1.GlobalTable{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "orders"},
+		Spec:       v1alpha1.GlobalTableSpec{GlobalTableName: aws.String("orders")},
+	}
+	for _, name := range names {
+		ko.Spec.ReplicationGroup = append(ko.Spec.ReplicationGroup, &v1alpha1.Replica{
+			TableRef: &ackv1alpha1.AWSResourceReferenceWrapper{
+				From: &ackv1alpha1.AWSResourceReference{Name: aws.String(name)},
+			},
+		})
+	}
+	return &resource{ko}
+}
+
+func newTestReader(t *testing.T, tables ...*v1alpha1.Table) client.Reader {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	for _, table := range tables {
+		builder = builder.WithObjects(table)
+	}
+	return builder.Build()
+}
+
+func TestResolveReferences_ReplicaTables(t *testing.T) {
+	rm := &resourceManager{}
+	ctx := context.Background()
+
+	reader := newTestReader(t,
+		newTestTable("orders-us", "us-west-2", "ACTIVE"),
+		newTestTable("orders-eu", "eu-west-1", "ACTIVE"),
+	)
+	resolved, hasReferences, err := rm.ResolveReferences(ctx, reader, newTableReferencingGlobalTable("orders-us", "orders-eu"))
+	require.NoError(t, err)
+	require.True(t, hasReferences)
+	require.Equal(t, []string{"eu-west-1", "us-west-2"}, replicaRegions(resolved.(*resource)))
+
+	cleared := rm.ClearResolvedReferences(resolved)
+	for _, replica := range cleared.(*resource).ko.Spec.ReplicationGroup {
+		require.Nil(t, replica.RegionName)
+		require.NotNil(t, replica.TableRef)
+	}
+
+	// Global tables are only created once every table is active.
+	reader = newTestReader(t,
+		newTestTable("orders-us", "us-west-2", "ACTIVE"),
+		newTestTable("orders-eu", "eu-west-1", "CREATING"),
+	)
+	_, _, err = rm.ResolveReferences(ctx, reader, newTableReferencingGlobalTable("orders-us", "orders-eu"))
+	var requeueErr *ackrequeue.RequeueNeededAfter
+	require.True(t, errors.As(err, &requeueErr))
+	require.Contains(t, err.Error(), "eu-west-1 (orders) is CREATING")
+
+	// A replica can't set both its Region and a table reference.
+	gt := newTableReferencingGlobalTable("orders-us")
+	gt.ko.Spec.ReplicationGroup[0].RegionName = aws.String("us-west-2")
+	_, _, err = rm.ResolveReferences(ctx, reader, gt)
+	require.True(t, errors.Is(err, ackerr.ResourceReferenceAndIDNotSupported))
+}
+
+func Test_compareReplicaTables(t *testing.T) {
+	us := newTestTable("orders-us", "us-west-2", "ACTIVE")
+	eu := newTestTable("orders-eu", "eu-west-1", "ACTIVE")
+	tables := []replicaTable{{region: "us-west-2", table: us}, {region: "eu-west-1", table: eu}}
+	require.Empty(t, compareReplicaTables("orders", tables))
+
+	eu.Spec.KeySchema = append(eu.Spec.KeySchema, &v1alpha1.KeySchemaElement{
+		AttributeName: aws.String("createdAt"), KeyType: aws.String("RANGE"),
+	})
+	eu.Spec.AttributeDefinitions = append(eu.Spec.AttributeDefinitions, &v1alpha1.AttributeDefinition{
+		AttributeName: aws.String("createdAt"), AttributeType: aws.String("N"),
+	})
+	eu.Spec.GlobalSecondaryIndexes = []*v1alpha1.GlobalSecondaryIndex{{
+		IndexName:  aws.String("byCreatedAt"),
+		KeySchema:  []*v1alpha1.KeySchemaElement{{AttributeName: aws.String("createdAt"), KeyType: aws.String("HASH")}},
+		Projection: &v1alpha1.Projection{ProjectionType: aws.String("KEYS_ONLY")},
+	}}
+	eu.Spec.StreamSpecification.StreamViewType = aws.String("NEW_IMAGE")
+	require.Equal(t, []string{
+		"eu-west-1 (orders): streamSpecification must be enabled with NEW_AND_OLD_IMAGES view type",
+		"eu-west-1 (orders): keySchema is [id:HASH,createdAt:RANGE], expected [id:HASH] as in us-west-2 (orders)",
+		"eu-west-1 (orders): attributeDefinitions is [createdAt:N,id:S], expected [id:S] as in us-west-2 (orders)",
+		"eu-west-1 (orders): globalSecondaryIndexes is [byCreatedAt(createdAt:HASH;KEYS_ONLY)], expected [] as in us-west-2 (orders)",
+	}, compareReplicaTables("orders", tables))
+}
@@ -0,0 +1,148 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package global_table
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws/aws-sdk-go/aws"
+	svcsdk "github.com/aws/aws-sdk-go/service/dynamodb"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+// ConditionTypeReplicasActive is the type of the condition reporting whether
+// every replica of a global table is active.
+const ConditionTypeReplicasActive ackv1alpha1.ConditionType = "ReplicasActive"
+
+// replicaStatusUnknown is reported for the replicas whose table doesn't exist.
+const replicaStatusUnknown = "UNKNOWN"
+
+// setReplicaStatuses sets Status.Replicas of the latest state of a global
+// table from the replicas described by DynamoDB, and the ReplicasActive
+// condition. DynamoDB doesn't report the status of the replicas of global
+// tables version 2017.11.29, it's read from their tables instead.
+func (rm *resourceManager) setReplicaStatuses(
+	ctx context.Context,
+	ko *v1alpha1.GlobalTable,
+	replicas []*svcsdk.ReplicaDescription,
+) error {
+	ko.Status.Replicas = newReplicaDescriptions(replicas)
+	for _, replica := range ko.Status.Replicas {
+		if replica.ReplicaStatus != nil || replica.RegionName == nil {
+			continue
+		}
+		table, err := rm.describeRegionalTable(ctx, rm.replicaAPIs.get(*replica.RegionName), ko.Spec.GlobalTableName)
+		if err != nil {
+			return err
+		}
+		setReplicaTableDescription(replica, table)
+	}
+	setReplicasActiveCondition(&resource{ko})
+	return nil
+}
+
+// setReplicaTableDescription sets the status, KMS key and table class of a
+// replica to the ones of its table.
+func setReplicaTableDescription(replica *v1alpha1.ReplicaDescription, table *svcsdk.TableDescription) {
+	if table == nil {
+		replica.ReplicaStatus = aws.String(replicaStatusUnknown)
+		return
+	}
+	replica.ReplicaStatus = table.TableStatus
+	if replica.KMSMasterKeyID == nil && table.SSEDescription != nil {
+		replica.KMSMasterKeyID = table.SSEDescription.KMSMasterKeyArn
+	}
+	if replica.ReplicaTableClassSummary == nil && table.TableClassSummary != nil {
+		replica.ReplicaTableClassSummary = newTableClassSummary(table.TableClassSummary)
+	}
+}
+
+// setReplicasActiveCondition sets the ReplicasActive condition of a global
+// table to false, listing the replicas that aren't active, or to true.
+func setReplicasActiveCondition(r *resource) {
+	var inactive []string
+	for _, replica := range r.ko.Status.Replicas {
+		status := aws.StringValue(replica.ReplicaStatus)
+		if status == svcsdk.ReplicaStatusActive {
+			continue
+		}
+		msg := fmt.Sprintf("%s is %s", aws.StringValue(replica.RegionName), status)
+		if progress := aws.StringValue(replica.ReplicaStatusPercentProgress); progress != "" {
+			msg += fmt.Sprintf(" (%s%%)", progress)
+		}
+		if description := aws.StringValue(replica.ReplicaStatusDescription); description != "" {
+			msg += ": " + description
+		}
+		inactive = append(inactive, msg)
+	}
+	if len(inactive) > 0 {
+		msg := "replicas not ACTIVE: " + strings.Join(inactive, ", ")
+		setConditionOfType(r, ConditionTypeReplicasActive, corev1.ConditionFalse, &msg, nil)
+		return
+	}
+	setConditionOfType(r, ConditionTypeReplicasActive, corev1.ConditionTrue, nil, nil)
+}
+
+// newReplicaDescriptions transforms a *svcsdk.ReplicaDescription array to a
+// *v1alpha1.ReplicaDescription array.
+func newReplicaDescriptions(replicas []*svcsdk.ReplicaDescription) []*v1alpha1.ReplicaDescription {
+	var descriptions []*v1alpha1.ReplicaDescription
+	for _, replica := range replicas {
+		description := &v1alpha1.ReplicaDescription{
+			KMSMasterKeyID:               replica.KMSMasterKeyId,
+			RegionName:                   replica.RegionName,
+			ReplicaStatus:                replica.ReplicaStatus,
+			ReplicaStatusDescription:     replica.ReplicaStatusDescription,
+			ReplicaStatusPercentProgress: replica.ReplicaStatusPercentProgress,
+		}
+		for _, gsi := range replica.GlobalSecondaryIndexes {
+			index := &v1alpha1.ReplicaGlobalSecondaryIndexDescription{IndexName: gsi.IndexName}
+			if gsi.ProvisionedThroughputOverride != nil {
+				index.ProvisionedThroughputOverride = &v1alpha1.ProvisionedThroughputOverride{
+					ReadCapacityUnits: gsi.ProvisionedThroughputOverride.ReadCapacityUnits,
+				}
+			}
+			description.GlobalSecondaryIndexes = append(description.GlobalSecondaryIndexes, index)
+		}
+		if replica.ProvisionedThroughputOverride != nil {
+			description.ProvisionedThroughputOverride = &v1alpha1.ProvisionedThroughputOverride{
+				ReadCapacityUnits: replica.ProvisionedThroughputOverride.ReadCapacityUnits,
+			}
+		}
+		if replica.ReplicaInaccessibleDateTime != nil {
+			description.ReplicaInaccessibleDateTime = &metav1.Time{Time: *replica.ReplicaInaccessibleDateTime}
+		}
+		if replica.ReplicaTableClassSummary != nil {
+			description.ReplicaTableClassSummary = newTableClassSummary(replica.ReplicaTableClassSummary)
+		}
+		descriptions = append(descriptions, description)
+	}
+	return descriptions
+}
+
+// newTableClassSummary transforms a *svcsdk.TableClassSummary to a
+// *v1alpha1.TableClassSummary.
+func newTableClassSummary(summary *svcsdk.TableClassSummary) *v1alpha1.TableClassSummary {
+	s := &v1alpha1.TableClassSummary{TableClass: summary.TableClass}
+	if summary.LastUpdateDateTime != nil {
+		s.LastUpdateDateTime = &metav1.Time{Time: *summary.LastUpdateDateTime}
+	}
+	return s
+}
@@ -0,0 +1,66 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package global_table
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	svcsdk "github.com/aws/aws-sdk-go/service/dynamodb"
+	svcsdkapi "github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func Test_setReplicaStatuses(t *testing.T) {
+	eu := &fakeDynamoDB{tableStatus: svcsdk.TableStatusCreating}
+	rm := &resourceManager{
+		metrics:     testMetrics,
+		replicaAPIs: &regionalAPIs{apis: map[string]svcsdkapi.DynamoDBAPI{"eu-west-1": eu}},
+	}
+	ctx := context.Background()
+	r := newGlobalTable([]string{"us-west-2", "eu-west-1"})
+	replicas := []*svcsdk.ReplicaDescription{
+		{
+			RegionName:                   aws.String("us-west-2"),
+			ReplicaStatus:                aws.String(svcsdk.ReplicaStatusUpdating),
+			ReplicaStatusPercentProgress: aws.String("40"),
+			KMSMasterKeyId:               aws.String("key"),
+			ReplicaTableClassSummary: &svcsdk.TableClassSummary{
+				TableClass: aws.String(svcsdk.TableClassStandard),
+			},
+		},
+		// The status of the replicas of global tables version 2017.11.29
+		// is read from their table.
+		{RegionName: aws.String("eu-west-1")},
+	}
+
+	require.NoError(t, rm.setReplicaStatuses(ctx, r.ko, replicas))
+	require.Len(t, r.ko.Status.Replicas, 2)
+	require.Equal(t, "key", *r.ko.Status.Replicas[0].KMSMasterKeyID)
+	require.Equal(t, svcsdk.TableClassStandard, *r.ko.Status.Replicas[0].ReplicaTableClassSummary.TableClass)
+	require.Equal(t, svcsdk.TableStatusCreating, *r.ko.Status.Replicas[1].ReplicaStatus)
+	condition := getConditionOfType(r, ConditionTypeReplicasActive)
+	require.NotNil(t, condition)
+	require.Equal(t, corev1.ConditionFalse, condition.Status)
+	require.Equal(t, "replicas not ACTIVE: us-west-2 is UPDATING (40%), eu-west-1 is CREATING", *condition.Message)
+
+	replicas[0].ReplicaStatus = aws.String(svcsdk.ReplicaStatusActive)
+	eu.tableStatus = svcsdk.TableStatusActive
+	require.NoError(t, rm.setReplicaStatuses(ctx, r.ko, replicas))
+	condition = getConditionOfType(r, ConditionTypeReplicasActive)
+	require.Equal(t, corev1.ConditionTrue, condition.Status)
+	require.Nil(t, condition.Message)
+}
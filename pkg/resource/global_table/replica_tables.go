@@ -0,0 +1,226 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package global_table
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	"github.com/aws/aws-sdk-go/aws"
+	svcsdk "github.com/aws/aws-sdk-go/service/dynamodb"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+// requeueWaitReplicaTables is the delay before the referenced replica tables
+// are checked again.
+const requeueWaitReplicaTables = 30 * time.Second
+
+// replicaTable is a table referenced by a replica of a global table.
+type replicaTable struct {
+	region string
+	table  *v1alpha1.Table
+}
+
+// String returns the Region and the name of the replica table.
+func (t replicaTable) String() string {
+	return fmt.Sprintf("%s (%s)", t.region, aws.StringValue(t.table.Spec.TableName))
+}
+
+// isGlobalTableCreated returns true if the supplied global table was created.
+func isGlobalTableCreated(ko *v1alpha1.GlobalTable) bool {
+	return ko.Status.ACKResourceMetadata != nil && ko.Status.ACKResourceMetadata.ARN != nil
+}
+
+// setReplicaTableRefs sets the table references of the replicas of ko to the
+// ones of the replicas of desired in the same Regions. DynamoDB only returns
+// the Regions of the replicas.
+func setReplicaTableRefs(desired *resource, ko *v1alpha1.GlobalTable) {
+	refs := map[string]*v1alpha1.Replica{}
+	for _, replica := range desired.ko.Spec.ReplicationGroup {
+		if replica != nil && replica.RegionName != nil && replica.TableRef != nil {
+			refs[*replica.RegionName] = replica
+		}
+	}
+	for _, replica := range ko.Spec.ReplicationGroup {
+		if ref, ok := refs[aws.StringValue(replica.RegionName)]; ok {
+			replica.TableRef = ref.TableRef.DeepCopy()
+		}
+	}
+}
+
+// checkReplicaTables reads the tables referenced by the replicas of a global
+// table, whose Regions are resolved, and checks they can form the global
+// table. It returns a requeue error while a table isn't active, and one
+// listing every mismatching field when the tables aren't compatible.
+func checkReplicaTables(
+	ctx context.Context,
+	apiReader client.Reader,
+	namespace string,
+	ko *v1alpha1.GlobalTable,
+) error {
+	var tables []replicaTable
+	var inactive []string
+	for _, replica := range ko.Spec.ReplicationGroup {
+		if replica == nil || replica.TableRef == nil || replica.TableRef.From == nil {
+			continue
+		}
+		table := &v1alpha1.Table{}
+		key := types.NamespacedName{Namespace: namespace, Name: aws.StringValue(replica.TableRef.From.Name)}
+		if err := apiReader.Get(ctx, key, table); err != nil {
+			return err
+		}
+		t := replicaTable{region: aws.StringValue(replica.RegionName), table: table}
+		if status := aws.StringValue(table.Status.TableStatus); status != svcsdk.TableStatusActive {
+			inactive = append(inactive, fmt.Sprintf("%s is %s", t, status))
+			continue
+		}
+		tables = append(tables, t)
+	}
+	if len(inactive) > 0 {
+		return ackrequeue.NeededAfter(
+			fmt.Errorf("waiting for replica tables to be ACTIVE: %s", strings.Join(inactive, ", ")),
+			requeueWaitReplicaTables,
+		)
+	}
+	if mismatches := compareReplicaTables(aws.StringValue(ko.Spec.GlobalTableName), tables); len(mismatches) > 0 {
+		return ackrequeue.NeededAfter(
+			fmt.Errorf("replica tables are not compatible: %s", strings.Join(mismatches, "; ")),
+			requeueWaitReplicaTables,
+		)
+	}
+	return nil
+}
+
+// compareReplicaTables returns the fields of the supplied replica tables that
+// prevent creating a global table from them. Every table must be named after
+// the global table and have streams with new and old images enabled, and its
+// key schema, key attribute definitions and global secondary indexes must
+// match the ones of the first table.
+func compareReplicaTables(globalTableName string, tables []replicaTable) []string {
+	var mismatches []string
+	for _, t := range tables {
+		spec := &t.table.Spec
+		if name := aws.StringValue(spec.TableName); name != globalTableName {
+			mismatches = append(mismatches, fmt.Sprintf(
+				"%s: tableName is %s, expected %s", t, name, globalTableName,
+			))
+		}
+		stream := spec.StreamSpecification
+		if stream == nil || !aws.BoolValue(stream.StreamEnabled) ||
+			aws.StringValue(stream.StreamViewType) != svcsdk.StreamViewTypeNewAndOldImages {
+			mismatches = append(mismatches, fmt.Sprintf(
+				"%s: streamSpecification must be enabled with %s view type", t, svcsdk.StreamViewTypeNewAndOldImages,
+			))
+		}
+	}
+	if len(tables) < 2 {
+		return mismatches
+	}
+
+	first := tables[0]
+	fields := []struct {
+		name   string
+		format func(*v1alpha1.TableSpec) string
+	}{
+		{"keySchema", func(spec *v1alpha1.TableSpec) string { return formatKeySchema(spec.KeySchema) }},
+		{"attributeDefinitions", formatKeyAttributeDefinitions},
+		{"globalSecondaryIndexes", formatGlobalSecondaryIndexes},
+	}
+	for _, t := range tables[1:] {
+		for _, field := range fields {
+			expected, actual := field.format(&first.table.Spec), field.format(&t.table.Spec)
+			if actual != expected {
+				mismatches = append(mismatches, fmt.Sprintf(
+					"%s: %s is [%s], expected [%s] as in %s", t, field.name, actual, expected, first,
+				))
+			}
+		}
+	}
+	return mismatches
+}
+
+// formatKeySchema returns the attributes and key types of a key schema.
+func formatKeySchema(keySchema []*v1alpha1.KeySchemaElement) string {
+	var elements []string
+	for _, ks := range keySchema {
+		if ks != nil {
+			elements = append(elements, aws.StringValue(ks.AttributeName)+":"+aws.StringValue(ks.KeyType))
+		}
+	}
+	return strings.Join(elements, ",")
+}
+
+// formatKeyAttributeDefinitions returns the sorted definitions of the
+// attributes of the key schemas of a table and of its indexes. The
+// definitions of other attributes aren't sent to DynamoDB.
+func formatKeyAttributeDefinitions(spec *v1alpha1.TableSpec) string {
+	keyAttributes := map[string]bool{}
+	keySchemas := [][]*v1alpha1.KeySchemaElement{spec.KeySchema}
+	for _, gsi := range spec.GlobalSecondaryIndexes {
+		if gsi != nil {
+			keySchemas = append(keySchemas, gsi.KeySchema)
+		}
+	}
+	for _, lsi := range spec.LocalSecondaryIndexes {
+		if lsi != nil {
+			keySchemas = append(keySchemas, lsi.KeySchema)
+		}
+	}
+	for _, keySchema := range keySchemas {
+		for _, ks := range keySchema {
+			if ks != nil {
+				keyAttributes[aws.StringValue(ks.AttributeName)] = true
+			}
+		}
+	}
+
+	var definitions []string
+	for _, ad := range spec.AttributeDefinitions {
+		if ad != nil && keyAttributes[aws.StringValue(ad.AttributeName)] {
+			definitions = append(definitions, aws.StringValue(ad.AttributeName)+":"+aws.StringValue(ad.AttributeType))
+		}
+	}
+	sort.Strings(definitions)
+	return strings.Join(definitions, ",")
+}
+
+// formatGlobalSecondaryIndexes returns the sorted names, key schemas and
+// projections of the global secondary indexes of a table.
+func formatGlobalSecondaryIndexes(spec *v1alpha1.TableSpec) string {
+	var indexes []string
+	for _, gsi := range spec.GlobalSecondaryIndexes {
+		if gsi == nil {
+			continue
+		}
+		index := aws.StringValue(gsi.IndexName) + "(" + formatKeySchema(gsi.KeySchema)
+		if p := gsi.Projection; p != nil {
+			nonKeyAttributes := aws.StringValueSlice(p.NonKeyAttributes)
+			sort.Strings(nonKeyAttributes)
+			index += ";" + aws.StringValue(p.ProjectionType)
+			if len(nonKeyAttributes) > 0 {
+				index += ":" + strings.Join(nonKeyAttributes, ",")
+			}
+		}
+		indexes = append(indexes, index+")")
+	}
+	sort.Strings(indexes)
+	return strings.Join(indexes, " ")
+}
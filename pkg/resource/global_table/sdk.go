@@ -75,6 +75,10 @@ func (rm *resourceManager) sdkFind(
 
 	var resp *svcsdk.DescribeGlobalTableOutput
 	resp, err = rm.sdkapi.DescribeGlobalTableWithContext(ctx, input)
+	err = requeueOnThrottle(r, err)
+	if teardown, ok := replicaTablesTeardown(r, err); ok {
+		return teardown, nil
+	}
 	rm.metrics.RecordAPICall("READ_ONE", "DescribeGlobalTable", err)
 	if err != nil {
 		if reqErr, ok := ackerr.AWSRequestFailure(err); ok && reqErr.StatusCode() == 404 {
@@ -127,6 +131,13 @@ func (rm *resourceManager) sdkFind(
 	}
 
 	rm.setStatusDefaults(ko)
+	setReplicaTableRefs(r, ko)
+	if err := rm.setReplicaStatuses(ctx, ko, resp.GlobalTableDescription.ReplicationGroup); err != nil {
+		return nil, requeueOnThrottle(r, err)
+	}
+	if err := rm.setReplicaTags(ctx, r, ko); err != nil {
+		return nil, err
+	}
 	return &resource{ko}, nil
 }
 
@@ -166,6 +177,9 @@ func (rm *resourceManager) sdkCreate(
 	defer func() {
 		exit(err)
 	}()
+	if desired, err = rm.ensureTemplateTables(ctx, desired); err != nil {
+		return desired, err
+	}
 	input, err := rm.newCreateRequestPayload(ctx, desired)
 	if err != nil {
 		return nil, err
@@ -174,6 +188,7 @@ func (rm *resourceManager) sdkCreate(
 	var resp *svcsdk.CreateGlobalTableOutput
 	_ = resp
 	resp, err = rm.sdkapi.CreateGlobalTableWithContext(ctx, input)
+	err = requeueOnThrottle(desired, err)
 	rm.metrics.RecordAPICall("CREATE", "CreateGlobalTable", err)
 	if err != nil {
 		return nil, err
@@ -219,6 +234,7 @@ func (rm *resourceManager) sdkCreate(
 	}
 
 	rm.setStatusDefaults(ko)
+	setReplicaTableRefs(desired, ko)
 	return &resource{ko}, nil
 }
 
@@ -255,82 +271,8 @@ func (rm *resourceManager) sdkUpdate(
 	desired *resource,
 	latest *resource,
 	delta *ackcompare.Delta,
-) (updated *resource, err error) {
-	rlog := ackrtlog.FromContext(ctx)
-	exit := rlog.Trace("rm.sdkUpdate")
-	defer func() {
-		exit(err)
-	}()
-	input, err := rm.newUpdateRequestPayload(ctx, desired, delta)
-	if err != nil {
-		return nil, err
-	}
-
-	var resp *svcsdk.UpdateGlobalTableOutput
-	_ = resp
-	resp, err = rm.sdkapi.UpdateGlobalTableWithContext(ctx, input)
-	rm.metrics.RecordAPICall("UPDATE", "UpdateGlobalTable", err)
-	if err != nil {
-		return nil, err
-	}
-	// Merge in the information we read from the API call above to the copy of
-	// the original Kubernetes object we passed to the function
-	ko := desired.ko.DeepCopy()
-
-	if resp.GlobalTableDescription.CreationDateTime != nil {
-		ko.Status.CreationDateTime = &metav1.Time{*resp.GlobalTableDescription.CreationDateTime}
-	} else {
-		ko.Status.CreationDateTime = nil
-	}
-	if ko.Status.ACKResourceMetadata == nil {
-		ko.Status.ACKResourceMetadata = &ackv1alpha1.ResourceMetadata{}
-	}
-	if resp.GlobalTableDescription.GlobalTableArn != nil {
-		arn := ackv1alpha1.AWSResourceName(*resp.GlobalTableDescription.GlobalTableArn)
-		ko.Status.ACKResourceMetadata.ARN = &arn
-	}
-	if resp.GlobalTableDescription.GlobalTableName != nil {
-		ko.Spec.GlobalTableName = resp.GlobalTableDescription.GlobalTableName
-	} else {
-		ko.Spec.GlobalTableName = nil
-	}
-	if resp.GlobalTableDescription.GlobalTableStatus != nil {
-		ko.Status.GlobalTableStatus = resp.GlobalTableDescription.GlobalTableStatus
-	} else {
-		ko.Status.GlobalTableStatus = nil
-	}
-	if resp.GlobalTableDescription.ReplicationGroup != nil {
-		f4 := []*svcapitypes.Replica{}
-		for _, f4iter := range resp.GlobalTableDescription.ReplicationGroup {
-			f4elem := &svcapitypes.Replica{}
-			if f4iter.RegionName != nil {
-				f4elem.RegionName = f4iter.RegionName
-			}
-			f4 = append(f4, f4elem)
-		}
-		ko.Spec.ReplicationGroup = f4
-	} else {
-		ko.Spec.ReplicationGroup = nil
-	}
-
-	rm.setStatusDefaults(ko)
-	return &resource{ko}, nil
-}
-
-// newUpdateRequestPayload returns an SDK-specific struct for the HTTP request
-// payload of the Update API call for the resource
-func (rm *resourceManager) newUpdateRequestPayload(
-	ctx context.Context,
-	r *resource,
-	delta *ackcompare.Delta,
-) (*svcsdk.UpdateGlobalTableInput, error) {
-	res := &svcsdk.UpdateGlobalTableInput{}
-
-	if r.ko.Spec.GlobalTableName != nil {
-		res.SetGlobalTableName(*r.ko.Spec.GlobalTableName)
-	}
-
-	return res, nil
+) (*resource, error) {
+	return rm.customUpdateGlobalTable(ctx, desired, latest, delta)
 }
 
 // sdkDelete deletes the supplied resource in the backend AWS service API
@@ -343,6 +285,9 @@ func (rm *resourceManager) sdkDelete(
 	defer func() {
 		exit(err)
 	}()
+	if isDeletingTables(r) {
+		return rm.deleteReplicaTables(ctx, r)
+	}
 	input, err := rm.newDeleteRequestPayload(r)
 	if err != nil {
 		return nil, err
@@ -351,6 +296,10 @@ func (rm *resourceManager) sdkDelete(
 	var resp *svcsdk.UpdateGlobalTableOutput
 	_ = resp
 	resp, err = rm.sdkapi.UpdateGlobalTableWithContext(ctx, input)
+	err = requeueOnThrottle(r, err)
+	if err == nil {
+		recordReplicasDetached(ctx, r)
+	}
 	rm.metrics.RecordAPICall("DELETE", "UpdateGlobalTable", err)
 	return nil, err
 }
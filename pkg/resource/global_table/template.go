@@ -0,0 +1,177 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package global_table
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go/aws"
+	svcsdk "github.com/aws/aws-sdk-go/service/dynamodb"
+	svcsdkapi "github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/tags"
+)
+
+// ensureTemplateTables creates from the table template of a global table the
+// tables of the Regions of its replication group that have none, and records
+// their Regions in Status.TemplateTableRegions. It returns a copy of the
+// global table with the recorded Regions, and a requeue error until the table
+// of every Region is active.
+func (rm *resourceManager) ensureTemplateTables(
+	ctx context.Context,
+	r *resource,
+) (updated *resource, err error) {
+	if r.ko.Spec.TableTemplate == nil {
+		return r, nil
+	}
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.ensureTemplateTables")
+	defer func() { exit(err) }()
+	defer func() {
+		err = requeueOnThrottle(r, err)
+	}()
+
+	ko := r.ko.DeepCopy()
+	var pending []string
+	for _, region := range replicaRegions(r) {
+		api := rm.replicaAPIs.get(region)
+		table, err := rm.describeRegionalTable(ctx, api, ko.Spec.GlobalTableName)
+		if err != nil {
+			return &resource{ko}, fmt.Errorf("cannot describe table in %s: %v", region, err)
+		}
+		if table == nil {
+			_, err = api.CreateTableWithContext(ctx, newTemplateCreateTableInput(ko))
+			rm.metrics.RecordAPICall("CREATE", "CreateTable", err)
+			if err != nil {
+				return &resource{ko}, fmt.Errorf("cannot create table in %s: %v", region, err)
+			}
+			ko.Status.TemplateTableRegions = append(ko.Status.TemplateTableRegions, aws.String(region))
+			pending = append(pending, fmt.Sprintf("%s is %s", region, svcsdk.TableStatusCreating))
+		} else if status := aws.StringValue(table.TableStatus); status != svcsdk.TableStatusActive {
+			pending = append(pending, fmt.Sprintf("%s is %s", region, status))
+		}
+	}
+	if len(pending) > 0 {
+		return &resource{ko}, ackrequeue.NeededAfter(
+			fmt.Errorf("waiting for regional tables to be ACTIVE: %s", strings.Join(pending, ", ")),
+			requeueWaitReplicaTables,
+		)
+	}
+	return &resource{ko}, nil
+}
+
+// describeRegionalTable returns the description of the table with the
+// supplied name, or nil if it doesn't exist.
+func (rm *resourceManager) describeRegionalTable(
+	ctx context.Context,
+	api svcsdkapi.DynamoDBAPI,
+	tableName *string,
+) (*svcsdk.TableDescription, error) {
+	resp, err := api.DescribeTableWithContext(ctx, &svcsdk.DescribeTableInput{TableName: tableName})
+	rm.metrics.RecordAPICall("READ_ONE", "DescribeTable", err)
+	if awsErr, ok := ackerr.AWSError(err); ok && awsErr.Code() == "ResourceNotFoundException" {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp.Table, nil
+}
+
+// newTemplateCreateTableInput returns the input creating a table of a global
+// table from its table template.
+func newTemplateCreateTableInput(ko *v1alpha1.GlobalTable) *svcsdk.CreateTableInput {
+	template := ko.Spec.TableTemplate
+	input := &svcsdk.CreateTableInput{
+		TableName:   ko.Spec.GlobalTableName,
+		BillingMode: aws.String(svcsdk.BillingModePayPerRequest),
+		StreamSpecification: &svcsdk.StreamSpecification{
+			StreamEnabled:  aws.Bool(true),
+			StreamViewType: aws.String(svcsdk.StreamViewTypeNewAndOldImages),
+		},
+	}
+	for _, ad := range template.AttributeDefinitions {
+		input.AttributeDefinitions = append(input.AttributeDefinitions, &svcsdk.AttributeDefinition{
+			AttributeName: ad.AttributeName,
+			AttributeType: ad.AttributeType,
+		})
+	}
+	if template.BillingMode != nil {
+		input.BillingMode = template.BillingMode
+	}
+	for _, gsi := range template.GlobalSecondaryIndexes {
+		index := &svcsdk.GlobalSecondaryIndex{
+			IndexName:             gsi.IndexName,
+			KeySchema:             newSDKKeySchema(gsi.KeySchema),
+			ProvisionedThroughput: newSDKProvisionedThroughput(gsi.ProvisionedThroughput),
+		}
+		if gsi.Projection != nil {
+			index.Projection = &svcsdk.Projection{
+				NonKeyAttributes: gsi.Projection.NonKeyAttributes,
+				ProjectionType:   gsi.Projection.ProjectionType,
+			}
+		}
+		input.GlobalSecondaryIndexes = append(input.GlobalSecondaryIndexes, index)
+	}
+	input.KeySchema = newSDKKeySchema(template.KeySchema)
+	input.ProvisionedThroughput = newSDKProvisionedThroughput(template.ProvisionedThroughput)
+	if sse := template.SSESpecification; sse != nil {
+		input.SSESpecification = &svcsdk.SSESpecification{
+			Enabled:        sse.Enabled,
+			KMSMasterKeyId: sse.KMSMasterKeyID,
+			SSEType:        sse.SSEType,
+		}
+	}
+	if stream := template.StreamSpecification; stream != nil {
+		input.StreamSpecification = &svcsdk.StreamSpecification{
+			StreamEnabled:  stream.StreamEnabled,
+			StreamViewType: stream.StreamViewType,
+		}
+	}
+	if len(ko.Spec.Tags) > 0 {
+		input.Tags = tags.ToSDK(ko.Spec.Tags)
+	}
+	return input
+}
+
+// newSDKKeySchema transforms a *v1alpha1.KeySchemaElement array to a
+// *svcsdk.KeySchemaElement array.
+func newSDKKeySchema(keySchema []*v1alpha1.KeySchemaElement) []*svcsdk.KeySchemaElement {
+	var elements []*svcsdk.KeySchemaElement
+	for _, ks := range keySchema {
+		elements = append(elements, &svcsdk.KeySchemaElement{
+			AttributeName: ks.AttributeName,
+			KeyType:       ks.KeyType,
+		})
+	}
+	return elements
+}
+
+// newSDKProvisionedThroughput transforms a *v1alpha1.ProvisionedThroughput to
+// a *svcsdk.ProvisionedThroughput.
+func newSDKProvisionedThroughput(pt *v1alpha1.ProvisionedThroughput) *svcsdk.ProvisionedThroughput {
+	if pt == nil {
+		return nil
+	}
+	return &svcsdk.ProvisionedThroughput{
+		ReadCapacityUnits:  pt.ReadCapacityUnits,
+		WriteCapacityUnits: pt.WriteCapacityUnits,
+	}
+}
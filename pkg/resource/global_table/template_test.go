@@ -0,0 +1,137 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package global_table
+
+import (
+	"context"
+	"testing"
+
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	svcsdk "github.com/aws/aws-sdk-go/service/dynamodb"
+	svcsdkapi "github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+var testMetrics = ackmetrics.NewMetrics("dynamodb")
+
+// fakeDynamoDB is the DynamoDB API of a Region with at most one table.
+type fakeDynamoDB struct {
+	svcsdkapi.DynamoDBAPI
+	tableStatus string
+	created     *svcsdk.CreateTableInput
+	deleted     bool
+	updated     *svcsdk.UpdateGlobalTableInput
+}
+
+func (f *fakeDynamoDB) DescribeTableWithContext(
+	ctx aws.Context,
+	input *svcsdk.DescribeTableInput,
+	opts ...request.Option,
+) (*svcsdk.DescribeTableOutput, error) {
+	if f.tableStatus == "" {
+		return nil, awserr.New("ResourceNotFoundException", "table not found", nil)
+	}
+	return &svcsdk.DescribeTableOutput{Table: &svcsdk.TableDescription{
+		TableName:   input.TableName,
+		TableStatus: aws.String(f.tableStatus),
+	}}, nil
+}
+
+func (f *fakeDynamoDB) CreateTableWithContext(
+	ctx aws.Context,
+	input *svcsdk.CreateTableInput,
+	opts ...request.Option,
+) (*svcsdk.CreateTableOutput, error) {
+	f.created = input
+	f.tableStatus = svcsdk.TableStatusCreating
+	return &svcsdk.CreateTableOutput{}, nil
+}
+
+func (f *fakeDynamoDB) DeleteTableWithContext(
+	ctx aws.Context,
+	input *svcsdk.DeleteTableInput,
+	opts ...request.Option,
+) (*svcsdk.DeleteTableOutput, error) {
+	if f.tableStatus == "" {
+		return nil, awserr.New("ResourceNotFoundException", "table not found", nil)
+	}
+	f.deleted = true
+	f.tableStatus = svcsdk.TableStatusDeleting
+	return &svcsdk.DeleteTableOutput{}, nil
+}
+
+func (f *fakeDynamoDB) UpdateGlobalTableWithContext(
+	ctx aws.Context,
+	input *svcsdk.UpdateGlobalTableInput,
+	opts ...request.Option,
+) (*svcsdk.UpdateGlobalTableOutput, error) {
+	f.updated = input
+	return &svcsdk.UpdateGlobalTableOutput{}, nil
+}
+
+func newTemplateGlobalTable(regions ...string) *resource {
+	r := newGlobalTable(regions)
+	r.ko.Spec.TableTemplate = &v1alpha1.TableTemplate{
+		AttributeDefinitions: []*v1alpha1.AttributeDefinition{
+			{AttributeName: aws.String("id"), AttributeType: aws.String("S")},
+		},
+		KeySchema: []*v1alpha1.KeySchemaElement{
+			{AttributeName: aws.String("id"), KeyType: aws.String("HASH")},
+		},
+	}
+	return r
+}
+
+func Test_ensureTemplateTables(t *testing.T) {
+	us := &fakeDynamoDB{tableStatus: svcsdk.TableStatusActive}
+	eu := &fakeDynamoDB{}
+	rm := &resourceManager{
+		metrics: testMetrics,
+		replicaAPIs: &regionalAPIs{apis: map[string]svcsdkapi.DynamoDBAPI{
+			"us-west-2": us,
+			"eu-west-1": eu,
+		}},
+	}
+	ctx := context.Background()
+
+	updated, err := rm.ensureTemplateTables(ctx, newTemplateGlobalTable("us-west-2", "eu-west-1"))
+	require.EqualError(t, err, "waiting for regional tables to be ACTIVE: eu-west-1 is CREATING")
+	require.Nil(t, us.created)
+	require.NotNil(t, eu.created)
+	require.Equal(t, "orders", *eu.created.TableName)
+	require.Equal(t, []string{"eu-west-1"}, aws.StringValueSlice(updated.ko.Status.TemplateTableRegions))
+
+	eu.tableStatus = svcsdk.TableStatusActive
+	updated, err = rm.ensureTemplateTables(ctx, updated)
+	require.NoError(t, err)
+	require.Equal(t, []string{"eu-west-1"}, aws.StringValueSlice(updated.ko.Status.TemplateTableRegions))
+}
+
+func Test_newTemplateCreateTableInput(t *testing.T) {
+	r := newTemplateGlobalTable("us-west-2")
+	r.ko.Spec.Tags = []*v1alpha1.Tag{Tag1}
+	input := newTemplateCreateTableInput(r.ko)
+	require.Equal(t, svcsdk.BillingModePayPerRequest, *input.BillingMode)
+	require.True(t, *input.StreamSpecification.StreamEnabled)
+	require.Equal(t, svcsdk.StreamViewTypeNewAndOldImages, *input.StreamSpecification.StreamViewType)
+	require.Len(t, input.KeySchema, 1)
+	require.Len(t, input.AttributeDefinitions, 1)
+	require.Len(t, input.Tags, 1)
+	require.NoError(t, input.Validate())
+}
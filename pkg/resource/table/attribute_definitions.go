@@ -0,0 +1,160 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"fmt"
+	"strings"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	svcsdk "github.com/aws/aws-sdk-go/service/dynamodb"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+// isAutoAttributeDefinitions returns true if the attribute definitions of the
+// supplied table are derived from its key schemas.
+func isAutoAttributeDefinitions(r *resource) bool {
+	return r.ko.GetAnnotations()[v1alpha1.AttributeDefinitionsAnnotation] == v1alpha1.AttributeDefinitionsAuto
+}
+
+// tableKeySchemas returns the key schemas of a table and of its indexes.
+func tableKeySchemas(spec *v1alpha1.TableSpec) [][]*v1alpha1.KeySchemaElement {
+	keySchemas := [][]*v1alpha1.KeySchemaElement{spec.KeySchema}
+	for _, gsi := range spec.GlobalSecondaryIndexes {
+		if gsi != nil {
+			keySchemas = append(keySchemas, gsi.KeySchema)
+		}
+	}
+	for _, lsi := range spec.LocalSecondaryIndexes {
+		if lsi != nil {
+			keySchemas = append(keySchemas, lsi.KeySchema)
+		}
+	}
+	return keySchemas
+}
+
+// deriveAttributeDefinitions returns the definitions of the attributes of the
+// supplied key schemas, in the order they first appear, with their type in
+// declared. It returns an error naming the attributes without a declared type
+// and the ones declared with conflicting types.
+func deriveAttributeDefinitions(
+	declared []*v1alpha1.AttributeDefinition,
+	keySchemas [][]*v1alpha1.KeySchemaElement,
+) ([]*v1alpha1.AttributeDefinition, error) {
+	types := map[string]string{}
+	var conflicts []string
+	for _, ad := range declared {
+		if ad == nil || ad.AttributeName == nil {
+			continue
+		}
+		name, attributeType := *ad.AttributeName, aws.StringValue(ad.AttributeType)
+		if t, ok := types[name]; ok && t != attributeType {
+			conflicts = append(conflicts, name)
+		}
+		types[name] = attributeType
+	}
+	if len(conflicts) > 0 {
+		return nil, fmt.Errorf(
+			"attributes declared with conflicting types: %s", strings.Join(conflicts, ", "),
+		)
+	}
+
+	var definitions []*v1alpha1.AttributeDefinition
+	var missing []string
+	seen := map[string]bool{}
+	for _, keySchema := range keySchemas {
+		for _, ks := range keySchema {
+			if ks == nil || ks.AttributeName == nil || seen[*ks.AttributeName] {
+				continue
+			}
+			name := *ks.AttributeName
+			seen[name] = true
+			attributeType, ok := types[name]
+			if !ok {
+				missing = append(missing, name)
+				continue
+			}
+			definitions = append(definitions, &v1alpha1.AttributeDefinition{
+				AttributeName: aws.String(name),
+				AttributeType: aws.String(attributeType),
+			})
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf(
+			"key attributes without declared type: %s", strings.Join(missing, ", "),
+		)
+	}
+	return definitions, nil
+}
+
+// withAttributeDefinitions returns a copy of the supplied table whose
+// attribute definitions are derived from its key schemas, when the table has
+// automatic attribute definitions. Tables with invalid definitions are
+// returned as is.
+func withAttributeDefinitions(r *resource) *resource {
+	if !isAutoAttributeDefinitions(r) {
+		return r
+	}
+	definitions, err := deriveAttributeDefinitions(r.ko.Spec.AttributeDefinitions, tableKeySchemas(&r.ko.Spec))
+	if err != nil {
+		return r
+	}
+	ko := r.ko.DeepCopy()
+	ko.Spec.AttributeDefinitions = definitions
+	return &resource{ko}
+}
+
+// newCreateAttributeDefinitions returns the attribute definitions sent to
+// create a table with automatic attribute definitions.
+func newCreateAttributeDefinitions(r *resource) ([]*svcsdk.AttributeDefinition, error) {
+	definitions, err := deriveAttributeDefinitions(r.ko.Spec.AttributeDefinitions, tableKeySchemas(&r.ko.Spec))
+	if err != nil {
+		return nil, ackerr.NewTerminalError(err)
+	}
+	return newSDKAttributesDefinition(definitions), nil
+}
+
+// newGlobalSecondaryIndexUpdateAttributeDefinitions returns the attribute
+// definitions sent to create or delete a global secondary index: the ones of
+// the key schemas of the table once the index is created or deleted, with the
+// types declared in the desired table. DynamoDB rejects both missing and
+// unused definitions.
+func newGlobalSecondaryIndexUpdateAttributeDefinitions(
+	desired *resource,
+	latest *resource,
+	created *v1alpha1.GlobalSecondaryIndex,
+	deleted *string,
+) []*svcsdk.AttributeDefinition {
+	spec := latest.ko.Spec.DeepCopy()
+	var gsis []*v1alpha1.GlobalSecondaryIndex
+	for _, gsi := range spec.GlobalSecondaryIndexes {
+		if gsi != nil && (deleted == nil || !equalStrings(gsi.IndexName, deleted)) {
+			gsis = append(gsis, gsi)
+		}
+	}
+	if created != nil {
+		gsis = append(gsis, created)
+	}
+	spec.GlobalSecondaryIndexes = gsis
+
+	definitions, err := deriveAttributeDefinitions(desired.ko.Spec.AttributeDefinitions, tableKeySchemas(spec))
+	if err != nil {
+		// Let DynamoDB report the invalid definitions.
+		definitions = desired.ko.Spec.AttributeDefinitions
+	}
+	return newSDKAttributesDefinition(definitions)
+}
@@ -0,0 +1,149 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+func newAttributeDefinition(name, attributeType string) *v1alpha1.AttributeDefinition {
+	return &v1alpha1.AttributeDefinition{
+		AttributeName: aws.String(name),
+		AttributeType: aws.String(attributeType),
+	}
+}
+
+func newKeySchema(names ...string) []*v1alpha1.KeySchemaElement {
+	keySchema := []*v1alpha1.KeySchemaElement{}
+	for i, name := range names {
+		keyType := "HASH"
+		if i > 0 {
+			keyType = "RANGE"
+		}
+		keySchema = append(keySchema, &v1alpha1.KeySchemaElement{
+			AttributeName: aws.String(name),
+			KeyType:       aws.String(keyType),
+		})
+	}
+	return keySchema
+}
+
+func newAutoAttributeDefinitionsTable() *resource {
+	return &resource{ko: &v1alpha1.Table{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				v1alpha1.AttributeDefinitionsAnnotation: v1alpha1.AttributeDefinitionsAuto,
+			},
+		},
+		Spec: v1alpha1.TableSpec{
+			TableName: aws.String("t"),
+			AttributeDefinitions: []*v1alpha1.AttributeDefinition{
+				newAttributeDefinition("pk", "S"),
+				newAttributeDefinition("sk", "S"),
+				newAttributeDefinition("gsi1pk", "N"),
+				newAttributeDefinition("unused", "B"),
+			},
+			KeySchema: newKeySchema("pk", "sk"),
+			GlobalSecondaryIndexes: []*v1alpha1.GlobalSecondaryIndex{{
+				IndexName: aws.String("gsi1"),
+				KeySchema: newKeySchema("gsi1pk", "sk"),
+			}},
+		},
+	}}
+}
+
+func Test_deriveAttributeDefinitions(t *testing.T) {
+	r := newAutoAttributeDefinitionsTable()
+
+	definitions, err := deriveAttributeDefinitions(r.ko.Spec.AttributeDefinitions, tableKeySchemas(&r.ko.Spec))
+	require.NoError(t, err)
+	require.Equal(t, []*v1alpha1.AttributeDefinition{
+		newAttributeDefinition("pk", "S"),
+		newAttributeDefinition("sk", "S"),
+		newAttributeDefinition("gsi1pk", "N"),
+	}, definitions)
+
+	_, err = deriveAttributeDefinitions(
+		r.ko.Spec.AttributeDefinitions[:2],
+		tableKeySchemas(&r.ko.Spec),
+	)
+	require.EqualError(t, err, "key attributes without declared type: gsi1pk")
+
+	_, err = deriveAttributeDefinitions(
+		append(r.ko.Spec.AttributeDefinitions, newAttributeDefinition("sk", "N")),
+		tableKeySchemas(&r.ko.Spec),
+	)
+	require.EqualError(t, err, "attributes declared with conflicting types: sk")
+}
+
+func Test_withAttributeDefinitions(t *testing.T) {
+	r := newAutoAttributeDefinitionsTable()
+	require.Len(t, withAttributeDefinitions(r).ko.Spec.AttributeDefinitions, 3)
+	require.Len(t, r.ko.Spec.AttributeDefinitions, 4)
+
+	r.ko.Annotations = nil
+	require.Equal(t, r, withAttributeDefinitions(r))
+
+	// Unused definitions of tables with automatic attribute definitions
+	// don't make a difference.
+	desired := newAutoAttributeDefinitionsTable()
+	latest := &resource{ko: desired.ko.DeepCopy()}
+	latest.ko.Spec.AttributeDefinitions = latest.ko.Spec.AttributeDefinitions[:3]
+	require.False(t, newResourceDelta(desired, latest).DifferentAt("Spec.AttributeDefinitions"))
+}
+
+func Test_newGlobalSecondaryIndexUpdateAttributeDefinitions(t *testing.T) {
+	latest := newAutoAttributeDefinitionsTable()
+	latest.ko.Spec.AttributeDefinitions = latest.ko.Spec.AttributeDefinitions[:3]
+	desired := newAutoAttributeDefinitionsTable()
+	desired.ko.Spec.AttributeDefinitions = append(
+		desired.ko.Spec.AttributeDefinitions, newAttributeDefinition("gsi2pk", "S"),
+	)
+	gsi2 := &v1alpha1.GlobalSecondaryIndex{
+		IndexName: aws.String("gsi2"),
+		KeySchema: newKeySchema("gsi2pk"),
+	}
+	desired.ko.Spec.GlobalSecondaryIndexes = append(desired.ko.Spec.GlobalSecondaryIndexes, gsi2)
+
+	names := func(r *resource, created *v1alpha1.GlobalSecondaryIndex, deleted *string) []string {
+		var names []string
+		for _, ad := range newGlobalSecondaryIndexUpdateAttributeDefinitions(desired, r, created, deleted) {
+			names = append(names, *ad.AttributeName)
+		}
+		return names
+	}
+	require.Equal(t, []string{"pk", "sk", "gsi1pk", "gsi2pk"}, names(latest, gsi2, nil))
+	require.Equal(t, []string{"pk", "sk"}, names(latest, nil, aws.String("gsi1")))
+	require.Equal(t, []string{"pk", "sk", "gsi1pk"}, names(latest, nil, nil))
+}
+
+func Test_equalAttributeDefinitions(t *testing.T) {
+	a := []*v1alpha1.AttributeDefinition{
+		newAttributeDefinition("pk", "S"),
+		newAttributeDefinition("pk", "S"),
+	}
+	b := []*v1alpha1.AttributeDefinition{
+		newAttributeDefinition("pk", "S"),
+		newAttributeDefinition("sk", "S"),
+	}
+	require.False(t, equalAttributeDefinitions(a, b))
+	require.False(t, equalAttributeDefinitions(b, a))
+	require.True(t, equalAttributeDefinitions(b, b))
+}
@@ -0,0 +1,215 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlrtmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+// additionalFieldsCacheTTL is the duration for which the table fields that
+// are not returned by DescribeTable are cached. The controller invalidates
+// the cached fields every time it modifies them, so the TTL only bounds how
+// long changes made outside of the controller can go unnoticed.
+const additionalFieldsCacheTTL = 5 * time.Minute
+
+// cachedField identifies a table field that is not returned by DescribeTable
+// and requires an additional API call to be read.
+type cachedField string
+
+const (
+	cachedFieldTags cachedField = "tags"
+	cachedFieldTTL  cachedField = "timeToLive"
+	cachedFieldPITR cachedField = "continuousBackups"
+)
+
+var (
+	additionalFieldsCacheLookups = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ack_dynamodb_table_fields_cache_lookups_total",
+			Help: "Total number of lookups of table fields that require additional API calls (tags, TTL, PITR), by result.",
+		},
+		[]string{
+			"field",
+			"result",
+		},
+	)
+)
+
+func init() {
+	ctrlrtmetrics.Registry.MustRegister(additionalFieldsCacheLookups)
+}
+
+// additionalFields is the process wide cache of table fields, keyed by table
+// ARN. ARNs contain both the account ID and region of the tables, so the
+// cache can safely be shared by all the resource managers.
+var additionalFields = newFieldsCache(additionalFieldsCacheTTL)
+
+// fieldsCache caches the table fields that are not returned by DescribeTable.
+type fieldsCache struct {
+	sync.Mutex
+	ttl     time.Duration
+	entries map[string]map[cachedField]*fieldsCacheEntry
+}
+
+type fieldsCacheEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+func newFieldsCache(ttl time.Duration) *fieldsCache {
+	return &fieldsCache{
+		ttl:     ttl,
+		entries: map[string]map[cachedField]*fieldsCacheEntry{},
+	}
+}
+
+// get returns the cached value of a table field, and whether it was found.
+func (c *fieldsCache) get(arn string, field cachedField) (interface{}, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	entry, found := c.entries[arn][field]
+	if !found || time.Now().After(entry.expiresAt) {
+		additionalFieldsCacheLookups.WithLabelValues(string(field), "miss").Inc()
+		return nil, false
+	}
+	additionalFieldsCacheLookups.WithLabelValues(string(field), "hit").Inc()
+	return entry.value, true
+}
+
+// set caches the value of a table field.
+func (c *fieldsCache) set(arn string, field cachedField, value interface{}) {
+	c.Lock()
+	defer c.Unlock()
+
+	now := time.Now()
+	c.gc(now)
+	if _, found := c.entries[arn]; !found {
+		c.entries[arn] = map[cachedField]*fieldsCacheEntry{}
+	}
+	c.entries[arn][field] = &fieldsCacheEntry{
+		value:     value,
+		expiresAt: now.Add(c.ttl),
+	}
+}
+
+// invalidate removes the supplied fields of a table from the cache. All the
+// fields of the table are removed if no field is supplied.
+func (c *fieldsCache) invalidate(arn string, fields ...cachedField) {
+	c.Lock()
+	defer c.Unlock()
+
+	if len(fields) == 0 {
+		delete(c.entries, arn)
+		return
+	}
+	for _, field := range fields {
+		delete(c.entries[arn], field)
+	}
+}
+
+// gc removes the expired entries from the cache.
+func (c *fieldsCache) gc(now time.Time) {
+	for arn, fields := range c.entries {
+		for field, entry := range fields {
+			if now.After(entry.expiresAt) {
+				delete(fields, field)
+			}
+		}
+		if len(fields) == 0 {
+			delete(c.entries, arn)
+		}
+	}
+}
+
+// tableARN returns the ARN of the supplied table, or an empty string if the
+// table wasn't created yet.
+func tableARN(r *resource) string {
+	if r.ko.Status.ACKResourceMetadata == nil || r.ko.Status.ACKResourceMetadata.ARN == nil {
+		return ""
+	}
+	return string(*r.ko.Status.ACKResourceMetadata.ARN)
+}
+
+// getCachedResourceTags returns the tags of a table, reading them from the
+// cache when possible.
+func (rm *resourceManager) getCachedResourceTags(
+	ctx context.Context,
+	arn string,
+) ([]*v1alpha1.Tag, error) {
+	if value, found := additionalFields.get(arn, cachedFieldTags); found {
+		return deepCopyTags(value.([]*v1alpha1.Tag)), nil
+	}
+	tags, err := rm.getResourceTagsPagesWithContext(ctx, arn)
+	if err != nil {
+		return nil, err
+	}
+	additionalFields.set(arn, cachedFieldTags, deepCopyTags(tags))
+	return tags, nil
+}
+
+// getCachedResourceTTL returns the TimeToLive specification of a table,
+// reading it from the cache when possible.
+func (rm *resourceManager) getCachedResourceTTL(
+	ctx context.Context,
+	arn string,
+	tableName *string,
+) (*v1alpha1.TimeToLiveSpecification, error) {
+	if value, found := additionalFields.get(arn, cachedFieldTTL); found {
+		return value.(*v1alpha1.TimeToLiveSpecification).DeepCopy(), nil
+	}
+	ttlSpec, err := rm.getResourceTTLWithContext(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+	additionalFields.set(arn, cachedFieldTTL, ttlSpec.DeepCopy())
+	return ttlSpec, nil
+}
+
+// getCachedResourcePointInTimeRecovery returns the point in time recovery
+// specification of a table, reading it from the cache when possible.
+func (rm *resourceManager) getCachedResourcePointInTimeRecovery(
+	ctx context.Context,
+	arn string,
+	tableName *string,
+) (*v1alpha1.PointInTimeRecoverySpecification, error) {
+	if value, found := additionalFields.get(arn, cachedFieldPITR); found {
+		return value.(*v1alpha1.PointInTimeRecoverySpecification).DeepCopy(), nil
+	}
+	pitrSpec, err := rm.getResourcePointInTimeRecoveryWithContext(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+	additionalFields.set(arn, cachedFieldPITR, pitrSpec.DeepCopy())
+	return pitrSpec, nil
+}
+
+// deepCopyTags returns a deep copy of the supplied tags.
+func deepCopyTags(tags []*v1alpha1.Tag) []*v1alpha1.Tag {
+	if tags == nil {
+		return nil
+	}
+	res := make([]*v1alpha1.Tag, len(tags))
+	for i := range tags {
+		res[i] = tags[i].DeepCopy()
+	}
+	return res
+}
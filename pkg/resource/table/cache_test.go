@@ -0,0 +1,57 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+func Test_fieldsCache(t *testing.T) {
+	arn := "arn:aws:dynamodb:us-west-2:111111111111:table/t"
+	tags := []*v1alpha1.Tag{Tag1}
+
+	t.Run("values are cached until invalidated", func(t *testing.T) {
+		c := newFieldsCache(time.Minute)
+		_, found := c.get(arn, cachedFieldTags)
+		require.False(t, found)
+
+		c.set(arn, cachedFieldTags, tags)
+		c.set(arn, cachedFieldTTL, &v1alpha1.TimeToLiveSpecification{})
+		value, found := c.get(arn, cachedFieldTags)
+		require.True(t, found)
+		require.Equal(t, tags, value)
+
+		c.invalidate(arn, cachedFieldTags)
+		_, found = c.get(arn, cachedFieldTags)
+		require.False(t, found)
+		_, found = c.get(arn, cachedFieldTTL)
+		require.True(t, found)
+
+		c.invalidate(arn)
+		_, found = c.get(arn, cachedFieldTTL)
+		require.False(t, found)
+	})
+
+	t.Run("values expire", func(t *testing.T) {
+		c := newFieldsCache(-time.Second)
+		c.set(arn, cachedFieldTags, tags)
+		_, found := c.get(arn, cachedFieldTags)
+		require.False(t, found)
+	})
+}
@@ -0,0 +1,176 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"fmt"
+	"time"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	"github.com/aws/aws-sdk-go/aws"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/cron"
+)
+
+var ErrWaitingCapacitySchedule = fmt.Errorf("waiting for the next capacity schedule entry")
+
+// parseCapacityScheduleEntry returns the parsed schedule and time zone of a
+// capacity schedule entry.
+func parseCapacityScheduleEntry(
+	entry *v1alpha1.CapacityScheduleEntry,
+) (*cron.Schedule, *time.Location, error) {
+	schedule, err := cron.Parse(aws.StringValue(entry.Schedule))
+	if err != nil {
+		return nil, nil, err
+	}
+	loc := time.UTC
+	if entry.TimeZone != nil {
+		if loc, err = time.LoadLocation(*entry.TimeZone); err != nil {
+			return nil, nil, fmt.Errorf("invalid time zone %q: %v", *entry.TimeZone, err)
+		}
+	}
+	return schedule, loc, nil
+}
+
+// activeCapacityScheduleEntry returns the entry of a capacity schedule active
+// at the supplied time, the one with the most recent activation, or nil if
+// no entry activated yet. It also returns the time the next entry activates.
+func activeCapacityScheduleEntry(
+	entries []*v1alpha1.CapacityScheduleEntry,
+	now time.Time,
+) (active *v1alpha1.CapacityScheduleEntry, next time.Time) {
+	var lastActivation time.Time
+	for _, entry := range entries {
+		if entry == nil {
+			continue
+		}
+		// Invalid entries are rejected by the validating webhook.
+		schedule, loc, err := parseCapacityScheduleEntry(entry)
+		if err != nil {
+			continue
+		}
+		t := now.In(loc)
+		if prev := schedule.Prev(t); !prev.IsZero() && prev.After(lastActivation) {
+			active, lastActivation = entry, prev
+		}
+		if n := schedule.Next(t); !n.IsZero() && (next.IsZero() || n.Before(next)) {
+			next = n
+		}
+	}
+	return active, next
+}
+
+// withCapacitySchedule returns a copy of the supplied table whose provisioned
+// throughputs are overridden by its active capacity schedule entry. The copy
+// has no capacity schedule, so that its throughputs can be changed further
+// without being overridden again.
+func withCapacitySchedule(r *resource) *resource {
+	if len(r.ko.Spec.CapacitySchedule) == 0 {
+		return r
+	}
+	ko := r.ko.DeepCopy()
+	ko.Spec.CapacitySchedule = nil
+	if isPayPerRequest(&r.ko.Spec) {
+		return &resource{ko}
+	}
+	entry, _ := activeCapacityScheduleEntry(r.ko.Spec.CapacitySchedule, time.Now())
+	if entry == nil {
+		return &resource{ko}
+	}
+	ko.Spec.ProvisionedThroughput = overrideThroughput(
+		ko.Spec.ProvisionedThroughput, entry.ProvisionedThroughput,
+	)
+	for _, index := range entry.GlobalSecondaryIndexes {
+		if index == nil {
+			continue
+		}
+		for _, gsi := range ko.Spec.GlobalSecondaryIndexes {
+			if gsi != nil && equalStrings(gsi.IndexName, index.IndexName) {
+				gsi.ProvisionedThroughput = overrideThroughput(
+					gsi.ProvisionedThroughput, index.ProvisionedThroughput,
+				)
+			}
+		}
+	}
+	return &resource{ko}
+}
+
+// overrideThroughput returns a copy of a provisioned throughput whose
+// capacity units are overridden by the ones set in override.
+func overrideThroughput(
+	pt *v1alpha1.ProvisionedThroughput,
+	override *v1alpha1.ProvisionedThroughput,
+) *v1alpha1.ProvisionedThroughput {
+	if override == nil {
+		return pt
+	}
+	res := pt.DeepCopy()
+	if res == nil {
+		res = &v1alpha1.ProvisionedThroughput{}
+	}
+	if override.ReadCapacityUnits != nil {
+		res.ReadCapacityUnits = aws.Int64(*override.ReadCapacityUnits)
+	}
+	if override.WriteCapacityUnits != nil {
+		res.WriteCapacityUnits = aws.Int64(*override.WriteCapacityUnits)
+	}
+	return res
+}
+
+// reportCapacitySchedule sets Status.ActiveCapacitySchedule in the latest
+// state of a table.
+func reportCapacitySchedule(desired, latest *resource) {
+	latest.ko.Status.ActiveCapacitySchedule = nil
+	entries := desired.ko.Spec.CapacitySchedule
+	if len(entries) == 0 || isPayPerRequest(&desired.ko.Spec) {
+		return
+	}
+	if active, _ := activeCapacityScheduleEntry(entries, time.Now()); active != nil {
+		latest.ko.Status.ActiveCapacitySchedule = active.Name
+	}
+}
+
+// nextCapacityScheduleActivation returns the time the next entry of the
+// capacity schedule of a table activates, or the zero time if the table has
+// no capacity schedule to apply.
+func nextCapacityScheduleActivation(r *resource) time.Time {
+	entries := r.ko.Spec.CapacitySchedule
+	if len(entries) == 0 || isPayPerRequest(&r.ko.Spec) {
+		return time.Time{}
+	}
+	_, next := activeCapacityScheduleEntry(entries, time.Now())
+	return next
+}
+
+// requeueCapacitySchedule returns the error requeueing the supplied table
+// when its next capacity schedule entry activates, or nil if it has none.
+func requeueCapacitySchedule(r *resource) error {
+	next := nextCapacityScheduleActivation(r)
+	if next.IsZero() {
+		return nil
+	}
+	return ackrequeue.NeededAfter(ErrWaitingCapacitySchedule, time.Until(next))
+}
+
+// compareCapacitySchedule adds a difference at Spec.CapacitySchedule to the
+// delta when the capacity schedule of the desired table has an entry to
+// activate. The reconciler then updates the table even when it is synced,
+// and customUpdateTable requeues it when the entry activates.
+func compareCapacitySchedule(delta *ackcompare.Delta, desired *resource) {
+	if !nextCapacityScheduleActivation(desired).IsZero() {
+		delta.Add("Spec.CapacitySchedule", desired.ko.Spec.CapacitySchedule, nil)
+	}
+}
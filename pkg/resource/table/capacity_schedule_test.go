@@ -0,0 +1,136 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+func Test_activeCapacityScheduleEntry(t *testing.T) {
+	entries := []*v1alpha1.CapacityScheduleEntry{{
+		Name:     aws.String("day"),
+		Schedule: aws.String("0 8 * * MON-FRI"),
+	}, {
+		Name:     aws.String("night"),
+		Schedule: aws.String("0 20 * * *"),
+	}, {
+		Name:     aws.String("invalid"),
+		Schedule: aws.String("not a schedule"),
+	}}
+
+	// Tuesday
+	now := time.Date(2023, time.March, 7, 12, 0, 0, 0, time.UTC)
+	active, next := activeCapacityScheduleEntry(entries, now)
+	require.Equal(t, "day", *active.Name)
+	require.Equal(t, time.Date(2023, time.March, 7, 20, 0, 0, 0, time.UTC), next)
+
+	// Sunday
+	now = time.Date(2023, time.March, 5, 12, 0, 0, 0, time.UTC)
+	active, next = activeCapacityScheduleEntry(entries, now)
+	require.Equal(t, "night", *active.Name)
+	require.Equal(t, time.Date(2023, time.March, 5, 20, 0, 0, 0, time.UTC), next)
+
+	active, next = activeCapacityScheduleEntry(nil, now)
+	require.Nil(t, active)
+	require.True(t, next.IsZero())
+}
+
+func Test_withCapacitySchedule(t *testing.T) {
+	r := &resource{ko: &v1alpha1.Table{Spec: v1alpha1.TableSpec{
+		BillingMode: aws.String(string(v1alpha1.BillingMode_PROVISIONED)),
+		ProvisionedThroughput: &v1alpha1.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(5),
+			WriteCapacityUnits: aws.Int64(5),
+		},
+		GlobalSecondaryIndexes: []*v1alpha1.GlobalSecondaryIndex{{
+			IndexName: aws.String("gsi"),
+			ProvisionedThroughput: &v1alpha1.ProvisionedThroughput{
+				ReadCapacityUnits:  aws.Int64(1),
+				WriteCapacityUnits: aws.Int64(1),
+			},
+		}},
+		CapacitySchedule: []*v1alpha1.CapacityScheduleEntry{{
+			Name:     aws.String("always"),
+			Schedule: aws.String("* * * * *"),
+			ProvisionedThroughput: &v1alpha1.ProvisionedThroughput{
+				ReadCapacityUnits: aws.Int64(50),
+			},
+			GlobalSecondaryIndexes: []*v1alpha1.IndexProvisionedThroughput{{
+				IndexName: aws.String("gsi"),
+				ProvisionedThroughput: &v1alpha1.ProvisionedThroughput{
+					WriteCapacityUnits: aws.Int64(10),
+				},
+			}},
+		}},
+	}}}
+
+	effective := withCapacitySchedule(r)
+	require.Equal(t, int64(50), *effective.ko.Spec.ProvisionedThroughput.ReadCapacityUnits)
+	require.Equal(t, int64(5), *effective.ko.Spec.ProvisionedThroughput.WriteCapacityUnits)
+	gsiPT := effective.ko.Spec.GlobalSecondaryIndexes[0].ProvisionedThroughput
+	require.Equal(t, int64(1), *gsiPT.ReadCapacityUnits)
+	require.Equal(t, int64(10), *gsiPT.WriteCapacityUnits)
+	// The supplied resource is left untouched.
+	require.Equal(t, int64(5), *r.ko.Spec.ProvisionedThroughput.ReadCapacityUnits)
+
+	latest := withCapacitySchedule(r)
+	reportCapacitySchedule(r, latest)
+	require.Equal(t, "always", *latest.ko.Status.ActiveCapacitySchedule)
+
+	err := requeueCapacitySchedule(r)
+	var requeueErr *ackrequeue.RequeueNeededAfter
+	require.True(t, errors.As(err, &requeueErr))
+	require.LessOrEqual(t, requeueErr.Duration(), time.Minute)
+	require.NoError(t, requeueCapacitySchedule(latest))
+}
+
+func Test_compareCapacitySchedule(t *testing.T) {
+	desired := &resource{ko: &v1alpha1.Table{Spec: v1alpha1.TableSpec{
+		BillingMode: aws.String(string(v1alpha1.BillingMode_PROVISIONED)),
+		ProvisionedThroughput: &v1alpha1.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(5),
+			WriteCapacityUnits: aws.Int64(5),
+		},
+		CapacitySchedule: []*v1alpha1.CapacityScheduleEntry{{
+			Name:     aws.String("always"),
+			Schedule: aws.String("* * * * *"),
+			ProvisionedThroughput: &v1alpha1.ProvisionedThroughput{
+				ReadCapacityUnits: aws.Int64(50),
+			},
+		}},
+	}}}
+	// The latest state of a table is read from a copy of the desired one.
+	latest := withCapacitySchedule(desired)
+	latest.ko.Spec.CapacitySchedule = desired.ko.Spec.CapacitySchedule
+
+	// Synced tables are still updated, to be requeued when the next entry
+	// activates.
+	delta := newResourceDelta(desired, latest)
+	require.True(t, delta.DifferentAt("Spec"))
+	require.False(t, delta.DifferentExcept("Spec.CapacitySchedule"))
+	require.Empty(t, withoutForcedDifferences(delta).Differences)
+
+	desired.ko.Spec.BillingMode = aws.String(string(v1alpha1.BillingMode_PAY_PER_REQUEST))
+	desired.ko.Spec.ProvisionedThroughput = nil
+	delta = newResourceDelta(desired, latest)
+	require.False(t, delta.DifferentAt("Spec.CapacitySchedule"))
+}
@@ -0,0 +1,239 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	"github.com/aws/aws-sdk-go/aws"
+	svcsdk "github.com/aws/aws-sdk-go/service/dynamodb"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+// ConditionTypeChangeRateLimited is the type of the condition reporting that
+// changes wait for the DynamoDB change rate limits.
+const ConditionTypeChangeRateLimited ackv1alpha1.ConditionType = "ChangeRateLimited"
+
+// DynamoDB change rate limits.
+// See https://docs.aws.amazon.com/amazondynamodb/latest/developerguide/ServiceQuotas.html#default-limits-throughput
+const (
+	// maxDecreasesAnytime is the number of throughput decreases allowed at
+	// any time of a UTC day.
+	maxDecreasesAnytime = 4
+	// maxDecreasesPerDay is the number of throughput decreases allowed per
+	// UTC day. After the first decreases, one decrease is allowed per hour.
+	maxDecreasesPerDay = 27
+	// decreaseInterval is the time between two throughput decreases, once
+	// the decreases allowed at any time are consumed.
+	decreaseInterval = time.Hour
+	// billingModeSwitchInterval is the time between two billing mode
+	// switches.
+	billingModeSwitchInterval = 24 * time.Hour
+)
+
+var ErrChangeRateLimited = fmt.Errorf("changes are waiting for the DynamoDB change rate limits")
+
+// newProvisionedThroughputDescription returns the provisioned throughput
+// description of a DescribeTable response.
+func newProvisionedThroughputDescription(
+	pt *svcsdk.ProvisionedThroughputDescription,
+) *v1alpha1.ProvisionedThroughputDescription {
+	if pt == nil {
+		return nil
+	}
+	res := &v1alpha1.ProvisionedThroughputDescription{
+		NumberOfDecreasesToday: pt.NumberOfDecreasesToday,
+		ReadCapacityUnits:      pt.ReadCapacityUnits,
+		WriteCapacityUnits:     pt.WriteCapacityUnits,
+	}
+	if pt.LastDecreaseDateTime != nil {
+		res.LastDecreaseDateTime = &metav1.Time{Time: *pt.LastDecreaseDateTime}
+	}
+	if pt.LastIncreaseDateTime != nil {
+		res.LastIncreaseDateTime = &metav1.Time{Time: *pt.LastIncreaseDateTime}
+	}
+	return res
+}
+
+// newBillingModeSummary returns the billing mode summary of a DescribeTable
+// response.
+func newBillingModeSummary(bms *svcsdk.BillingModeSummary) *v1alpha1.BillingModeSummary {
+	if bms == nil {
+		return nil
+	}
+	res := &v1alpha1.BillingModeSummary{
+		BillingMode: bms.BillingMode,
+	}
+	if bms.LastUpdateToPayPerRequestDateTime != nil {
+		res.LastUpdateToPayPerRequestDateTime = &metav1.Time{Time: *bms.LastUpdateToPayPerRequestDateTime}
+	}
+	return res
+}
+
+// nextThroughputDecrease returns the time the throughput described by pt can
+// be decreased, or the zero time if it can be decreased now.
+func nextThroughputDecrease(pt *v1alpha1.ProvisionedThroughputDescription, now time.Time) time.Time {
+	if pt == nil || aws.Int64Value(pt.NumberOfDecreasesToday) < maxDecreasesAnytime {
+		return time.Time{}
+	}
+	now = now.UTC()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+	if aws.Int64Value(pt.NumberOfDecreasesToday) >= maxDecreasesPerDay {
+		return tomorrow
+	}
+	if pt.LastDecreaseDateTime == nil {
+		return time.Time{}
+	}
+	next := pt.LastDecreaseDateTime.Add(decreaseInterval)
+	if !next.After(now) {
+		return time.Time{}
+	}
+	if next.After(tomorrow) {
+		return tomorrow
+	}
+	return next
+}
+
+// nextBillingModeSwitch returns the time the billing mode of a table can be
+// switched, or the zero time if it can be switched now.
+func nextBillingModeSwitch(bms *v1alpha1.BillingModeSummary, now time.Time) time.Time {
+	if bms == nil || bms.LastUpdateToPayPerRequestDateTime == nil {
+		return time.Time{}
+	}
+	next := bms.LastUpdateToPayPerRequestDateTime.Add(billingModeSwitchInterval)
+	if !next.After(now) {
+		return time.Time{}
+	}
+	return next
+}
+
+// isThroughputDecrease returns true if the read or write capacity units of
+// the desired throughput are lower than the latest ones.
+func isThroughputDecrease(desired, latest *v1alpha1.ProvisionedThroughput) bool {
+	if desired == nil || latest == nil {
+		return false
+	}
+	return aws.Int64Value(desired.ReadCapacityUnits) < aws.Int64Value(latest.ReadCapacityUnits) ||
+		aws.Int64Value(desired.WriteCapacityUnits) < aws.Int64Value(latest.WriteCapacityUnits)
+}
+
+// deferRateLimitedChanges returns a copy of the desired table without the
+// changes DynamoDB doesn't allow yet, along with its delta with the latest
+// table, the time the first of these changes is allowed and their
+// descriptions. The time is zero if no changes are deferred.
+func deferRateLimitedChanges(
+	desired *resource,
+	latest *resource,
+	delta *ackcompare.Delta,
+	now time.Time,
+) (*resource, *ackcompare.Delta, time.Time, []string) {
+	var next time.Time
+	var reasons []string
+	deferUntil := func(t time.Time, reason string) {
+		if next.IsZero() || t.Before(next) {
+			next = t
+		}
+		reasons = append(reasons, fmt.Sprintf("%s until %s", reason, t.UTC().Format(time.RFC3339)))
+	}
+
+	ko := desired.ko.DeepCopy()
+	if delta.DifferentAt("Spec.BillingMode") {
+		if t := nextBillingModeSwitch(latest.ko.Status.BillingModeSummary, now); !t.IsZero() {
+			// Throughput changes may only be valid with the new billing mode.
+			ko.Spec.BillingMode = latest.ko.Spec.BillingMode
+			ko.Spec.ProvisionedThroughput = latest.ko.Spec.ProvisionedThroughput
+			deferUntil(t, "billing mode switch")
+		}
+	}
+	if delta.DifferentAt("Spec.ProvisionedThroughput") &&
+		isThroughputDecrease(ko.Spec.ProvisionedThroughput, latest.ko.Spec.ProvisionedThroughput) {
+		if t := nextThroughputDecrease(latest.ko.Status.ProvisionedThroughputDescription, now); !t.IsZero() {
+			ko.Spec.ProvisionedThroughput = latest.ko.Spec.ProvisionedThroughput
+			deferUntil(t, "table throughput decrease")
+		}
+	}
+	if delta.DifferentAt("Spec.GlobalSecondaryIndexes") {
+		for _, gsi := range ko.Spec.GlobalSecondaryIndexes {
+			if gsi == nil {
+				continue
+			}
+			latestGSI := findGlobalSecondaryIndex(latest.ko.Spec.GlobalSecondaryIndexes, gsi.IndexName)
+			if latestGSI == nil || !isThroughputDecrease(gsi.ProvisionedThroughput, latestGSI.ProvisionedThroughput) {
+				continue
+			}
+			description := findGlobalSecondaryIndexDescription(latest.ko.Status.GlobalSecondaryIndexesDescriptions, gsi.IndexName)
+			if description == nil {
+				continue
+			}
+			if t := nextThroughputDecrease(description.ProvisionedThroughput, now); !t.IsZero() {
+				gsi.ProvisionedThroughput = latestGSI.ProvisionedThroughput.DeepCopy()
+				deferUntil(t, "global secondary index "+*gsi.IndexName+" throughput decrease")
+			}
+		}
+	}
+	if next.IsZero() {
+		return desired, delta, next, nil
+	}
+	deferred := &resource{ko}
+	return deferred, newResourceDelta(deferred, latest), next, reasons
+}
+
+// findGlobalSecondaryIndex returns the global secondary index with the
+// supplied name, or nil if there is none.
+func findGlobalSecondaryIndex(gsis []*v1alpha1.GlobalSecondaryIndex, name *string) *v1alpha1.GlobalSecondaryIndex {
+	for _, gsi := range gsis {
+		if gsi != nil && equalStrings(gsi.IndexName, name) {
+			return gsi
+		}
+	}
+	return nil
+}
+
+// findGlobalSecondaryIndexDescription returns the description of the global
+// secondary index with the supplied name, or nil if there is none.
+func findGlobalSecondaryIndexDescription(
+	descriptions []*v1alpha1.GlobalSecondaryIndexDescription,
+	name *string,
+) *v1alpha1.GlobalSecondaryIndexDescription {
+	for _, description := range descriptions {
+		if description != nil && equalStrings(description.IndexName, name) {
+			return description
+		}
+	}
+	return nil
+}
+
+// setWaitingChangeRateLimits reports in ko the changes waiting for the
+// DynamoDB change rate limits, and returns the error requeueing the resource
+// when the first of them is allowed.
+func setWaitingChangeRateLimits(ko *v1alpha1.Table, next time.Time, reasons []string) error {
+	msg := "DynamoDB doesn't allow yet: " + strings.Join(reasons, ", ")
+	setConditionOfType(&resource{ko}, ConditionTypeChangeRateLimited, corev1.ConditionTrue, &msg, nil)
+	setSyncedCondition(&resource{ko}, corev1.ConditionFalse, &msg, nil)
+	return ackrequeue.NeededAfter(ErrChangeRateLimited, time.Until(next))
+}
+
+// clearWaitingChangeRateLimits reports in ko that no changes are waiting for
+// the DynamoDB change rate limits.
+func clearWaitingChangeRateLimits(ko *v1alpha1.Table) {
+	removeConditionOfType(&resource{ko}, ConditionTypeChangeRateLimited)
+}
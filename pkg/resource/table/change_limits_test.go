@@ -0,0 +1,114 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+func Test_nextThroughputDecrease(t *testing.T) {
+	now := time.Date(2023, time.March, 7, 12, 30, 0, 0, time.UTC)
+	tomorrow := time.Date(2023, time.March, 8, 0, 0, 0, 0, time.UTC)
+	description := func(decreases int64, lastDecrease time.Time) *v1alpha1.ProvisionedThroughputDescription {
+		return &v1alpha1.ProvisionedThroughputDescription{
+			NumberOfDecreasesToday: aws.Int64(decreases),
+			LastDecreaseDateTime:   &metav1.Time{Time: lastDecrease},
+		}
+	}
+
+	require.True(t, nextThroughputDecrease(nil, now).IsZero())
+	require.True(t, nextThroughputDecrease(description(3, now), now).IsZero())
+	require.Equal(t,
+		now.Add(30*time.Minute),
+		nextThroughputDecrease(description(4, now.Add(-30*time.Minute)), now),
+	)
+	require.True(t, nextThroughputDecrease(description(10, now.Add(-2*time.Hour)), now).IsZero())
+	require.Equal(t, tomorrow, nextThroughputDecrease(description(27, now.Add(-2*time.Hour)), now))
+}
+
+func Test_nextBillingModeSwitch(t *testing.T) {
+	now := time.Date(2023, time.March, 7, 12, 30, 0, 0, time.UTC)
+	summary := func(lastSwitch time.Time) *v1alpha1.BillingModeSummary {
+		return &v1alpha1.BillingModeSummary{
+			LastUpdateToPayPerRequestDateTime: &metav1.Time{Time: lastSwitch},
+		}
+	}
+	require.True(t, nextBillingModeSwitch(nil, now).IsZero())
+	require.True(t, nextBillingModeSwitch(summary(now.Add(-25*time.Hour)), now).IsZero())
+	require.Equal(t, now.Add(time.Hour), nextBillingModeSwitch(summary(now.Add(-23*time.Hour)), now))
+}
+
+func Test_deferRateLimitedChanges(t *testing.T) {
+	now := time.Date(2023, time.March, 7, 12, 30, 0, 0, time.UTC)
+	latest := &resource{ko: &v1alpha1.Table{
+		Spec: v1alpha1.TableSpec{
+			TableName:   aws.String("t"),
+			BillingMode: aws.String(string(v1alpha1.BillingMode_PROVISIONED)),
+			ProvisionedThroughput: &v1alpha1.ProvisionedThroughput{
+				ReadCapacityUnits:  aws.Int64(10),
+				WriteCapacityUnits: aws.Int64(10),
+			},
+			Tags: []*v1alpha1.Tag{Tag1},
+		},
+		Status: v1alpha1.TableStatus{
+			ProvisionedThroughputDescription: &v1alpha1.ProvisionedThroughputDescription{
+				NumberOfDecreasesToday: aws.Int64(4),
+				LastDecreaseDateTime:   &metav1.Time{Time: now.Add(-10 * time.Minute)},
+			},
+		},
+	}}
+
+	t.Run("increase", func(t *testing.T) {
+		desired := &resource{ko: latest.ko.DeepCopy()}
+		desired.ko.Spec.ProvisionedThroughput.ReadCapacityUnits = aws.Int64(20)
+		delta := newResourceDelta(desired, latest)
+		r, d, next, reasons := deferRateLimitedChanges(desired, latest, delta, now)
+		require.True(t, next.IsZero())
+		require.Empty(t, reasons)
+		require.Equal(t, desired, r)
+		require.Equal(t, delta, d)
+	})
+
+	t.Run("decrease", func(t *testing.T) {
+		desired := &resource{ko: latest.ko.DeepCopy()}
+		desired.ko.Spec.ProvisionedThroughput.ReadCapacityUnits = aws.Int64(5)
+		desired.ko.Spec.Tags = []*v1alpha1.Tag{Tag2}
+		delta := newResourceDelta(desired, latest)
+		_, d, next, reasons := deferRateLimitedChanges(desired, latest, delta, now)
+		require.Equal(t, now.Add(50*time.Minute), next)
+		require.Len(t, reasons, 1)
+		require.False(t, d.DifferentAt("Spec.ProvisionedThroughput"))
+		require.True(t, d.DifferentAt("Spec.Tags"))
+	})
+}
+
+func Test_setWaitingChangeRateLimits(t *testing.T) {
+	ko := &v1alpha1.Table{}
+	next := time.Now().Add(time.Hour)
+	require.Error(t, setWaitingChangeRateLimits(ko, next, []string{"table throughput decrease"}))
+	cond := getConditionOfType(&resource{ko}, ConditionTypeChangeRateLimited)
+	require.Equal(t, corev1.ConditionTrue, cond.Status)
+	require.Contains(t, *cond.Message, "table throughput decrease")
+
+	clearWaitingChangeRateLimits(ko)
+	require.Nil(t, getConditionOfType(&resource{ko}, ConditionTypeChangeRateLimited))
+}
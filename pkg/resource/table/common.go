@@ -13,6 +13,32 @@
 
 package table
 
+import (
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+)
+
+// forcedDifferencePaths are the paths of the differences added to the delta
+// of a table to have the reconciler update it while it is synced, by
+// compareCapacitySchedule and compareReplacement. Both fields are otherwise
+// ignored by the comparison.
+var forcedDifferencePaths = []string{"Spec.CapacitySchedule", "Spec.ReplacementStrategy"}
+
+// withoutForcedDifferences returns a copy of the supplied delta without the
+// differences at forcedDifferencePaths.
+func withoutForcedDifferences(delta *ackcompare.Delta) *ackcompare.Delta {
+	res := ackcompare.NewDelta()
+	for _, diff := range delta.Differences {
+		forced := false
+		for _, path := range forcedDifferencePaths {
+			forced = forced || diff.Path.Contains(path)
+		}
+		if !forced {
+			res.Differences = append(res.Differences, diff)
+		}
+	}
+	return res
+}
+
 // TODO(hilalymh) Move these functions to aws-controllers-k8s/runtime
 
 func emptyString(s *string) bool {
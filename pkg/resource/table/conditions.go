@@ -75,6 +75,8 @@ func setSyncedCondition(
 	now := metav1.Now()
 	c.LastTransitionTime = &now
 	c.Status = status
+	c.Message = message
+	c.Reason = reason
 }
 
 // setTerminalCondition sets the resource's Condition of type
@@ -100,3 +102,41 @@ func setTerminalCondition(
 	c.Message = message
 	c.Reason = reason
 }
+
+// setConditionOfType sets the resource's Condition of the supplied type to
+// the supplied status, optional message and reason.
+func setConditionOfType(
+	r *resource,
+	condType ackv1alpha1.ConditionType,
+	status corev1.ConditionStatus,
+	message *string,
+	reason *string,
+) {
+	c := getConditionOfType(r, condType)
+	if c == nil {
+		c = &ackv1alpha1.Condition{
+			Type: condType,
+		}
+		r.ko.Status.Conditions = append(r.ko.Status.Conditions, c)
+	}
+	now := metav1.Now()
+	c.LastTransitionTime = &now
+	c.Status = status
+	c.Message = message
+	c.Reason = reason
+}
+
+// removeConditionOfType removes the resource's Condition of the supplied type,
+// if any.
+func removeConditionOfType(
+	r *resource,
+	condType ackv1alpha1.ConditionType,
+) {
+	conditions := r.ko.Status.Conditions[:0]
+	for _, condition := range r.ko.Status.Conditions {
+		if condition.Type != condType {
+			conditions = append(conditions, condition)
+		}
+	}
+	r.ko.Status.Conditions = conditions
+}
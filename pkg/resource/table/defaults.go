@@ -0,0 +1,85 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+// defaultTableSpec fills the fields of a Table spec that were left empty
+// with the values DynamoDB uses by default, so that the spec describes the
+// table explicitly.
+func defaultTableSpec(spec *v1alpha1.TableSpec) {
+	if spec.BillingMode == nil {
+		spec.BillingMode = aws.String(string(v1alpha1.BillingMode_PROVISIONED))
+	}
+	if spec.TableClass == nil {
+		spec.TableClass = aws.String(string(v1alpha1.TableClass_STANDARD))
+	}
+	// PAY_PER_REQUEST tables don't have a provisioned throughput, DynamoDB
+	// reports it as zero read and write capacity units.
+	// See https://github.com/aws-controllers-k8s/community/issues/1595
+	if isPayPerRequest(spec) && !hasCapacity(spec.ProvisionedThroughput) {
+		spec.ProvisionedThroughput = nil
+	}
+	if spec.TimeToLive == nil {
+		spec.TimeToLive = &v1alpha1.TimeToLiveSpecification{}
+	}
+	if spec.TimeToLive.Enabled == nil {
+		spec.TimeToLive.Enabled = aws.Bool(DefaultTTLEnabledValue)
+	}
+	if spec.ContinuousBackups == nil {
+		spec.ContinuousBackups = &v1alpha1.PointInTimeRecoverySpecification{}
+	}
+	if spec.ContinuousBackups.PointInTimeRecoveryEnabled == nil {
+		spec.ContinuousBackups.PointInTimeRecoveryEnabled = aws.Bool(DefaultPITREnabledValue)
+	}
+	if spec.SSESpecification == nil {
+		spec.SSESpecification = &v1alpha1.SSESpecification{}
+	}
+	if spec.SSESpecification.Enabled == nil {
+		spec.SSESpecification.Enabled = aws.Bool(false)
+	}
+	if spec.StreamSpecification == nil {
+		spec.StreamSpecification = &v1alpha1.StreamSpecification{}
+	}
+	if spec.StreamSpecification.StreamEnabled == nil {
+		spec.StreamSpecification.StreamEnabled = aws.Bool(false)
+	}
+	for _, gsi := range spec.GlobalSecondaryIndexes {
+		if gsi == nil {
+			continue
+		}
+		if gsi.Projection == nil {
+			gsi.Projection = &v1alpha1.Projection{}
+		}
+		if gsi.Projection.ProjectionType == nil {
+			gsi.Projection.ProjectionType = aws.String(string(v1alpha1.ProjectionType_ALL))
+		}
+		if isPayPerRequest(spec) && !hasCapacity(gsi.ProvisionedThroughput) {
+			gsi.ProvisionedThroughput = nil
+		}
+	}
+}
+
+// withDefaults returns a copy of the supplied resource with a defaulted
+// spec. It is used to compare resources that weren't defaulted by the
+// mutating webhook without modifying them.
+func withDefaults(r *resource) *resource {
+	ko := r.ko.DeepCopy()
+	defaultTableSpec(&ko.Spec)
+	return &resource{ko}
+}
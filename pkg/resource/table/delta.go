@@ -42,6 +42,9 @@ func newResourceDelta(
 		delta.Add("", a, b)
 		return delta
 	}
+	compareCapacitySchedule(delta, a)
+	compareReplacement(delta, a)
+	a, b = withDefaults(withAttributeDefinitions(withCapacitySchedule(a))), withDefaults(b)
 	customPreCompare(delta, a, b)
 
 	if ackcompare.HasNilDifference(a.ko.Spec.BillingMode, b.ko.Spec.BillingMode) {
@@ -0,0 +1,75 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/dependents"
+)
+
+// ConditionTypeDependentsExist is the type of the condition reporting that a
+// table being deleted is referenced by other resources.
+const ConditionTypeDependentsExist ackv1alpha1.ConditionType = "DependentsExist"
+
+var (
+	ErrDependentsExist = fmt.Errorf("table is referenced by other resources and cannot be deleted")
+
+	requeueWaitDependentsDeleted = ackrequeue.NeededAfter(
+		ErrDependentsExist,
+		30*time.Second,
+	)
+)
+
+// holdDeletionForDependents returns a copy of the supplied table and a
+// requeue error while Backups or GlobalTables of its namespace reference it,
+// unless it has the ignore-dependents annotation. The DependentsExist
+// condition of the copy reports the dependents, or the failure to list them.
+// It returns nil once the table can be deleted.
+func holdDeletionForDependents(ctx context.Context, r *resource) (*resource, error) {
+	if r.ko.GetAnnotations()[v1alpha1.IgnoreDependentsAnnotation] == "true" {
+		return nil, nil
+	}
+	deps, err := dependents.OfTable(ctx, r.ko)
+	return holdForDependents(r, "table", deps, err)
+}
+
+// holdForDependents returns a copy of the supplied table and a requeue error
+// when the supplied dependents of the described table, or the error listing
+// them, hold its deletion. It returns nil otherwise.
+func holdForDependents(r *resource, table string, deps []string, err error) (*resource, error) {
+	if err == nil && len(deps) == 0 {
+		return nil, nil
+	}
+	latest := &resource{r.ko.DeepCopy()}
+	if err != nil {
+		msg := fmt.Sprintf("cannot list the resources referencing the %s: %v", table, err)
+		setConditionOfType(latest, ConditionTypeDependentsExist, corev1.ConditionUnknown, &msg, nil)
+		return latest, requeueWaitDependentsDeleted
+	}
+	msg := fmt.Sprintf(
+		"%s is not deleted while referenced by %s: delete them first or set the %s annotation to \"true\"",
+		table, strings.Join(deps, ", "), v1alpha1.IgnoreDependentsAnnotation,
+	)
+	setConditionOfType(latest, ConditionTypeDependentsExist, corev1.ConditionTrue, &msg, nil)
+	return latest, requeueWaitDependentsDeleted
+}
@@ -0,0 +1,64 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/dependents"
+)
+
+func Test_holdDeletionForDependents(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&v1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "nightly"},
+		Spec:       v1alpha1.BackupSpec{TableName: aws.String("orders")},
+	}).Build()
+	ctx := dependents.NewContext(context.Background(), reader)
+	ko := &v1alpha1.Table{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "orders"},
+		Spec:       v1alpha1.TableSpec{TableName: aws.String("orders")},
+	}
+
+	latest, err := holdDeletionForDependents(ctx, &resource{ko})
+	require.Equal(t, requeueWaitDependentsDeleted, err)
+	cond := getConditionOfType(latest, ConditionTypeDependentsExist)
+	require.Equal(t, corev1.ConditionTrue, cond.Status)
+	require.Contains(t, *cond.Message, "Backup/nightly")
+	// The supplied table is left untouched.
+	require.Nil(t, getConditionOfType(&resource{ko}, ConditionTypeDependentsExist))
+
+	ko.Annotations = map[string]string{v1alpha1.IgnoreDependentsAnnotation: "true"}
+	latest, err = holdDeletionForDependents(ctx, &resource{ko})
+	require.NoError(t, err)
+	require.Nil(t, latest)
+
+	// Failing to list the dependents holds the deletion.
+	ko.Annotations = nil
+	ctx = dependents.NewContext(context.Background(), fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build())
+	latest, err = holdDeletionForDependents(ctx, &resource{ko})
+	require.Equal(t, requeueWaitDependentsDeleted, err)
+	cond = getConditionOfType(latest, ConditionTypeDependentsExist)
+	require.Equal(t, corev1.ConditionUnknown, cond.Status)
+}
@@ -0,0 +1,152 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	"github.com/aws/aws-sdk-go/aws"
+	svcsdk "github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/throttle"
+)
+
+// ConditionTypeEncryptionKeyInaccessible is the type of the condition
+// reporting that DynamoDB can't access the KMS key of a table.
+const ConditionTypeEncryptionKeyInaccessible ackv1alpha1.ConditionType = "EncryptionKeyInaccessible"
+
+// archivalDelay is the time after which DynamoDB archives a table whose KMS
+// key is inaccessible.
+const archivalDelay = 7 * 24 * time.Hour
+
+var (
+	ErrEncryptionKeyInaccessible = fmt.Errorf("table KMS key is inaccessible")
+
+	requeueWaitEncryptionKeyAccessible = ackrequeue.NeededAfter(
+		ErrEncryptionKeyInaccessible,
+		5*time.Minute,
+	)
+	// DynamoDB takes a few minutes to notice that a key is enabled again.
+	requeueWaitEncryptionKeyRestored = ackrequeue.NeededAfter(
+		ErrEncryptionKeyInaccessible,
+		time.Minute,
+	)
+)
+
+// isEncryptionKeyInaccessible returns true if DynamoDB can't access the KMS
+// key of the supplied table.
+func isEncryptionKeyInaccessible(r *resource) bool {
+	return aws.StringValue(r.ko.Status.TableStatus) ==
+		string(v1alpha1.TableStatus_SDK_INACCESSIBLE_ENCRYPTION_CREDENTIALS)
+}
+
+// isTableArchived returns true if the supplied table was archived.
+func isTableArchived(r *resource) bool {
+	return aws.StringValue(r.ko.Status.TableStatus) == string(v1alpha1.TableStatus_SDK_ARCHIVED)
+}
+
+// reportInaccessibleEncryptionKey sets the EncryptionKeyInaccessible and
+// Synced conditions of a table whose KMS key is inaccessible, and returns the
+// error requeuing it until the key is accessible again. The state of the key
+// is read from the supplied KMS API, failing to read it is reported in the
+// conditions.
+func (rm *resourceManager) reportInaccessibleEncryptionKey(
+	ctx context.Context,
+	kmsapi kmsiface.KMSAPI,
+	ko *v1alpha1.Table,
+	sse *svcsdk.SSEDescription,
+) error {
+	var keyID string
+	var since *time.Time
+	if sse != nil {
+		keyID = aws.StringValue(sse.KMSMasterKeyArn)
+		since = sse.InaccessibleEncryptionDateTime
+	}
+	msg := fmt.Sprintf("KMS key %s is inaccessible", keyID)
+	if since != nil {
+		msg += fmt.Sprintf(
+			" since %s, the table will be archived after %s",
+			since.UTC().Format(time.RFC3339), since.Add(archivalDelay).UTC().Format(time.RFC3339),
+		)
+	}
+
+	errRequeue := requeueWaitEncryptionKeyAccessible
+	if keyID != "" {
+		keyState, err := rm.describeKeyState(ctx, kmsapi, keyID)
+		switch {
+		case err != nil:
+			msg += fmt.Sprintf("; cannot read key state: %v", err)
+		case keyState == kms.KeyStateEnabled:
+			msg += "; key is enabled again, waiting for DynamoDB to access it"
+			errRequeue = requeueWaitEncryptionKeyRestored
+		default:
+			msg += "; key state: " + keyState
+		}
+	}
+
+	setConditionOfType(&resource{ko}, ConditionTypeEncryptionKeyInaccessible, corev1.ConditionTrue, &msg, nil)
+	setSyncedCondition(&resource{ko}, corev1.ConditionFalse, &msg, nil)
+	return errRequeue
+}
+
+// newKMSAPI returns the KMS API reading the state of the table keys. It is
+// only used for the tables whose key is inaccessible.
+func (rm *resourceManager) newKMSAPI() kmsiface.KMSAPI {
+	kmsapi := kms.New(rm.sess)
+	throttle.Instrument(kmsapi.Client)
+	return kmsapi
+}
+
+// describeKeyState returns the state of the KMS key with the supplied ID.
+func (rm *resourceManager) describeKeyState(
+	ctx context.Context,
+	kmsapi kmsiface.KMSAPI,
+	keyID string,
+) (string, error) {
+	resp, err := kmsapi.DescribeKeyWithContext(ctx, &kms.DescribeKeyInput{
+		KeyId: aws.String(keyID),
+	})
+	rm.metrics.RecordAPICall("READ_ONE", "DescribeKey", err)
+	if err != nil {
+		return "", err
+	}
+	if resp.KeyMetadata == nil {
+		return "", nil
+	}
+	return aws.StringValue(resp.KeyMetadata.KeyState), nil
+}
+
+// newTableArchivedError returns the terminal error reported for an archived
+// table.
+func newTableArchivedError(ko *v1alpha1.Table) error {
+	msg := "table was archived"
+	if summary := ko.Status.ArchivalSummary; summary != nil {
+		if summary.ArchivalReason != nil {
+			msg += ", reason: " + *summary.ArchivalReason
+		}
+		if summary.ArchivalBackupARN != nil {
+			msg += ", it can be restored from backup " + *summary.ArchivalBackupARN
+		}
+	}
+	return ackerr.NewTerminalError(fmt.Errorf(msg))
+}
@@ -0,0 +1,88 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	svcsdk "github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+type fakeKMS struct {
+	kmsiface.KMSAPI
+	keyState string
+}
+
+func (f *fakeKMS) DescribeKeyWithContext(
+	ctx aws.Context,
+	input *kms.DescribeKeyInput,
+	opts ...request.Option,
+) (*kms.DescribeKeyOutput, error) {
+	return &kms.DescribeKeyOutput{KeyMetadata: &kms.KeyMetadata{
+		KeyId:    input.KeyId,
+		KeyState: aws.String(f.keyState),
+	}}, nil
+}
+
+func Test_reportInaccessibleEncryptionKey(t *testing.T) {
+	since := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	sse := &svcsdk.SSEDescription{
+		KMSMasterKeyArn:                aws.String(testKeyARN),
+		InaccessibleEncryptionDateTime: aws.Time(since),
+	}
+	kmsapi := &fakeKMS{keyState: kms.KeyStateDisabled}
+	rm := &resourceManager{metrics: ackmetrics.NewMetrics("dynamodb")}
+
+	ko := &v1alpha1.Table{}
+	err := rm.reportInaccessibleEncryptionKey(context.TODO(), kmsapi, ko, sse)
+	require.Equal(t, requeueWaitEncryptionKeyAccessible, err)
+	cond := getConditionOfType(&resource{ko}, ConditionTypeEncryptionKeyInaccessible)
+	require.NotNil(t, cond)
+	require.Equal(t, corev1.ConditionTrue, cond.Status)
+	require.Contains(t, *cond.Message, testKeyARN)
+	require.Contains(t, *cond.Message, "2026-03-01T10:00:00Z")
+	require.Contains(t, *cond.Message, "2026-03-08T10:00:00Z")
+	require.Contains(t, *cond.Message, "key state: Disabled")
+	require.Equal(t, corev1.ConditionFalse, getSyncedCondition(&resource{ko}).Status)
+
+	// Once the key is enabled again, the table is checked more often.
+	kmsapi.keyState = kms.KeyStateEnabled
+	ko = &v1alpha1.Table{}
+	err = rm.reportInaccessibleEncryptionKey(context.TODO(), kmsapi, ko, sse)
+	require.Equal(t, requeueWaitEncryptionKeyRestored, err)
+}
+
+func Test_newTableArchivedError(t *testing.T) {
+	ko := &v1alpha1.Table{}
+	ko.Status.ArchivalSummary = &v1alpha1.ArchivalSummary{
+		ArchivalReason:    aws.String("INACCESSIBLE_ENCRYPTION_CREDENTIALS"),
+		ArchivalBackupARN: aws.String("arn:aws:dynamodb:us-west-2:123456789012:table/t/backup/b"),
+	}
+	err := newTableArchivedError(ko)
+	var terminal *ackerr.TerminalError
+	require.ErrorAs(t, err, &terminal)
+	require.Contains(t, err.Error(), "INACCESSIBLE_ENCRYPTION_CREDENTIALS")
+}
@@ -17,6 +17,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
@@ -29,6 +30,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/approval"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/tags"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/throttle"
 )
 
 var (
@@ -54,6 +58,7 @@ var (
 // DynamoDB table
 var TerminalStatuses = []v1alpha1.TableStatus_SDK{
 	v1alpha1.TableStatus_SDK_ARCHIVING,
+	v1alpha1.TableStatus_SDK_ARCHIVED,
 	v1alpha1.TableStatus_SDK_DELETING,
 }
 
@@ -96,6 +101,13 @@ func tableHasTerminalStatus(r *resource) bool {
 	return false
 }
 
+// requeueOnThrottle turns throttling and quota errors returned by the DynamoDB
+// API into a requeue with a jittered exponential delay.
+func requeueOnThrottle(r *resource, err error) error {
+	key := fmt.Sprintf("table/%s/%s", r.ko.Namespace, r.ko.Name)
+	return throttle.RequeueOnThrottle(key, err)
+}
+
 // isTableCreating returns true if the supplied DynamodbDB table is in the process
 // of being created
 func isTableCreating(r *resource) bool {
@@ -135,8 +147,36 @@ func (rm *resourceManager) customUpdateTable(
 	rlog := ackrtlog.FromContext(ctx)
 	exit := rlog.Trace("rm.customUpdateTable")
 	defer func(err error) { exit(err) }(err)
+	defer func() {
+		err = requeueOnThrottle(desired, err)
+	}()
+	// The differences at the capacity schedule and the replacement strategy
+	// only make the reconciler update the table: the schedule is applied with
+	// the provisioned throughputs, the replaced table is deleted below.
+	delta = withoutForcedDifferences(delta)
+
+	if isObserveOnly(desired) {
+		// Observe-only tables are never modified, the differences are
+		// reported in Status.Drift by sdkFind.
+		ko := desired.ko.DeepCopy()
+		latest.ko.Status.DeepCopyInto(&ko.Status)
+		return &resource{ko}, nil
+	}
 
-	if immutableFieldChanges := rm.getImmutableFieldChanges(delta); len(immutableFieldChanges) > 0 {
+	// The table replaced by the resource is deleted once the switch to its
+	// new table is recorded in the status of the resource.
+	if isDeletingReplacedTable(desired) {
+		completed, err := rm.deleteReplacedTable(ctx, desired)
+		if err != nil {
+			return completed, err
+		}
+		desired = completed
+	}
+
+	// Immutable changes are made by replacing the table when the resource
+	// opted in.
+	immutableFieldChanges := rm.getImmutableFieldChanges(delta)
+	if len(immutableFieldChanges) > 0 && desired.ko.Spec.ReplacementStrategy == nil {
 		msg := fmt.Sprintf(
 			"Immutable Spec fields have been modified: %s",
 			strings.Join(immutableFieldChanges, ","),
@@ -170,71 +210,334 @@ func (rm *resourceManager) customUpdateTable(
 	// the original Kubernetes object we passed to the function
 	ko := desired.ko.DeepCopy()
 	rm.setStatusDefaults(ko)
-
-	if delta.DifferentAt("Spec.Tags") {
-		if err := rm.syncTableTags(ctx, desired, latest); err != nil {
-			return nil, err
+	ko.Status.ActiveCapacitySchedule = latest.ko.Status.ActiveCapacitySchedule
+	ko.Status.BillingModeSummary = latest.ko.Status.BillingModeSummary
+	ko.Status.ProvisionedThroughputDescription = latest.ko.Status.ProvisionedThroughputDescription
+	ko.Status.ForeignTags = latest.ko.Status.ForeignTags
+	ko.Status.ManagedTagKeys = latest.ko.Status.ManagedTagKeys
+	ko.Status.PhysicalTableName = latest.ko.Status.PhysicalTableName
+	// The provisioned throughputs are set by the active capacity schedule
+	// entry, if any.
+	errWaitSchedule := requeueCapacitySchedule(desired)
+	desired = withCapacitySchedule(desired)
+
+	if len(immutableFieldChanges) > 0 {
+		// The table is replaced with the whole desired spec, nothing is
+		// deferred.
+		updates := rm.newTableUpdates(ko, delta, desired, latest)
+		if hold, err := holdUpdate(ko, desired, newUpdatePlan(updates)); hold {
+			return &resource{ko}, err
+		}
+		return rm.applyTableUpdates(ctx, updates)
+	}
+
+	// Changes putting load on the table wait for its maintenance window, the
+	// other changes are made right away.
+	desired, delta, nextWindow, err := deferToMaintenanceWindow(desired, latest, delta)
+	if err != nil {
+		return nil, err
+	}
+	var errWait error
+	if !nextWindow.IsZero() {
+		errWait = setWaitingMaintenanceWindow(ko, nextWindow)
+	}
+	// Changes DynamoDB doesn't allow yet are deferred as well, instead of
+	// failing on each reconciliation.
+	desired, delta, nextAllowed, reasons := deferRateLimitedChanges(desired, latest, delta, time.Now())
+	if !nextAllowed.IsZero() {
+		errWaitRateLimits := setWaitingChangeRateLimits(ko, nextAllowed, reasons)
+		if nextWindow.IsZero() || nextAllowed.Before(nextWindow) {
+			errWait = errWaitRateLimits
 		}
+	} else {
+		clearWaitingChangeRateLimits(ko)
+	}
+
+	// The plan only holds the changes that are not deferred.
+	updates := rm.newTableUpdates(ko, delta, desired, latest)
+	if hold, err := holdUpdate(ko, desired, newUpdatePlan(updates)); hold {
+		return &resource{ko}, err
+	}
+	updated, err = rm.applyTableUpdates(ctx, updates)
+	if updated != nil || err != nil {
+		return updated, err
 	}
 	if !delta.DifferentExcept("Spec.Tags") {
-		return &resource{ko}, nil
+		if errWait == nil && errWaitSchedule != nil {
+			// The table is synced until the next capacity schedule entry
+			// activates.
+			setSyncedCondition(&resource{ko}, corev1.ConditionTrue, nil, nil)
+			errWait = errWaitSchedule
+		}
+		return &resource{ko}, errWait
 	}
+	return &resource{ko}, requeueWaitWhileUpdating
+}
 
-	if delta.DifferentAt("Spec.TimeToLive") {
-		if err := rm.syncTTL(ctx, desired, latest); err != nil {
-			// Ignore "already disabled errors"
-			if awsErr, ok := ackerr.AWSError(err); ok && !(awsErr.Code() == "ValidationException" &&
-				strings.HasPrefix(awsErr.Message(), "TimeToLive is already disabled")) {
-				return nil, err
+// tableUpdate is a step of the update of a table.
+type tableUpdate struct {
+	// ops are the operations of the step, reported in the update plan.
+	ops []*v1alpha1.PlannedOperation
+	// apply makes the operations. It returns the table and the error to
+	// return to the reconciler when the next steps must wait for a later
+	// reconciliation, nil otherwise.
+	apply func(ctx context.Context) (*resource, error)
+}
+
+// newTableUpdates returns the ordered steps converging the latest state of a
+// table to its desired state. customUpdateTable makes them and the update
+// plan describes them. ko is the table returned to the reconciler.
+func (rm *resourceManager) newTableUpdates(
+	ko *v1alpha1.Table,
+	delta *ackcompare.Delta,
+	desired *resource,
+	latest *resource,
+) []*tableUpdate {
+	// Describe the changes with the defaulted specs, the same way they are
+	// compared.
+	d, l := withDefaults(desired).ko.Spec, withDefaults(latest).ko.Spec
+
+	if len(rm.getImmutableFieldChanges(delta)) > 0 && desired.ko.Spec.ReplacementStrategy != nil {
+		// The new table is created with the whole desired spec.
+		return []*tableUpdate{{
+			ops: []*v1alpha1.PlannedOperation{approval.NewPlannedOperation(
+				"CreateTable", approval.ActionReplaceTable,
+				describeReplacement(desired),
+			)},
+			apply: func(ctx context.Context) (*resource, error) {
+				return rm.replaceTable(ctx, ko, desired)
+			},
+		}}
+	}
+
+	var updates []*tableUpdate
+	if delta.DifferentAt("Spec.Tags") {
+		var ops []*v1alpha1.PlannedOperation
+		added, removed := tags.ComputeDelta(d.Tags, l.Tags)
+		if len(removed) > 0 {
+			ops = append(ops, approval.NewPlannedOperation(
+				"UntagResource", approval.ActionRemoveTags,
+				"remove tags "+strings.Join(aws.StringValueSlice(removed), ", "),
+			))
+		}
+		if len(added) > 0 {
+			keys := make([]string, 0, len(added))
+			for _, tag := range added {
+				keys = append(keys, aws.StringValue(tag.Key))
 			}
-		}
+			ops = append(ops, approval.NewPlannedOperation(
+				"TagResource", approval.ActionAddTags,
+				"add or update tags "+strings.Join(keys, ", "),
+			))
+		}
+		updates = append(updates, &tableUpdate{
+			ops: ops,
+			apply: func(ctx context.Context) (*resource, error) {
+				if err := rm.syncTableTags(ctx, desired, latest); err != nil {
+					return nil, err
+				}
+				setManagedTagKeys(ko, desired)
+				return nil, nil
+			},
+		})
+	}
+	if !delta.DifferentExcept("Spec.Tags") {
+		return updates
 	}
 
-	if delta.DifferentAt("Spec.SSESpecification") {
-		if err := rm.syncTableSSESpecification(ctx, desired); err != nil {
-			return nil, fmt.Errorf("cannot update table %v", err)
-		}
+	if delta.DifferentAt("Spec.TimeToLive") {
+		var ops []*v1alpha1.PlannedOperation
+		if needsTimeToLiveTransition(desired, latest) {
+			ops = append(ops, approval.NewPlannedOperation(
+				"UpdateTimeToLive", approval.ActionUpdateTimeToLive,
+				"disable TimeToLive on attribute "+aws.StringValue(l.TimeToLive.AttributeName)+
+					" before enabling it on its new attribute",
+			))
+		}
+		ops = append(ops, approval.NewPlannedOperation(
+			"UpdateTimeToLive", approval.ActionUpdateTimeToLive,
+			describeChange("TimeToLive", l.TimeToLive, d.TimeToLive),
+		))
+		updates = append(updates, &tableUpdate{
+			ops: ops,
+			apply: func(ctx context.Context) (*resource, error) {
+				synced, err := rm.syncTimeToLive(ctx, ko, desired, latest)
+				if err != nil {
+					// Ignore "already disabled errors"
+					if awsErr, ok := ackerr.AWSError(err); ok && !(awsErr.Code() == "ValidationException" &&
+						strings.HasPrefix(awsErr.Message(), "TimeToLive is already disabled")) {
+						return nil, err
+					}
+					synced = true
+				}
+				if !synced && !delta.DifferentExcept("Spec.Tags", "Spec.TimeToLive") {
+					msg := "waiting for the time to live to be disabled before enabling it on its new attribute"
+					setSyncedCondition(&resource{ko}, corev1.ConditionFalse, &msg, nil)
+					return &resource{ko}, requeueWaitTimeToLiveDisabled
+				}
+				return nil, nil
+			},
+		})
 	}
 
-	if delta.DifferentAt("Spec.BillingMode") ||
-		delta.DifferentAt("Spec.TableClass") {
-		if err := rm.syncTable(ctx, desired, delta); err != nil {
-			return nil, fmt.Errorf("cannot update table %v", err)
-		}
+	if delta.DifferentAt("Spec.SSESpecification") {
+		updates = append(updates, &tableUpdate{
+			ops: []*v1alpha1.PlannedOperation{approval.NewPlannedOperation(
+				"UpdateTable", approval.ActionUpdateSSESpecification,
+				describeChange("SSESpecification", l.SSESpecification, d.SSESpecification),
+			)},
+			apply: func(ctx context.Context) (*resource, error) {
+				if err := rm.syncTableSSESpecification(ctx, desired); err != nil {
+					return nil, fmt.Errorf("cannot update table %v", err)
+				}
+				return nil, nil
+			},
+		})
+	}
+
+	if delta.DifferentAt("Spec.BillingMode") || delta.DifferentAt("Spec.TableClass") {
+		var ops []*v1alpha1.PlannedOperation
+		if delta.DifferentAt("Spec.BillingMode") {
+			ops = append(ops, approval.NewPlannedOperation(
+				"UpdateTable", approval.ActionUpdateBillingMode,
+				describeChange("BillingMode", l.BillingMode, d.BillingMode),
+			))
+		}
+		if delta.DifferentAt("Spec.TableClass") {
+			ops = append(ops, approval.NewPlannedOperation(
+				"UpdateTable", approval.ActionUpdateTableClass,
+				describeChange("TableClass", l.TableClass, d.TableClass),
+			))
+		}
+		updates = append(updates, &tableUpdate{
+			ops: ops,
+			apply: func(ctx context.Context) (*resource, error) {
+				if delta.DifferentAt("Spec.BillingMode") && !isPayPerRequest(&desired.ko.Spec) {
+					if _, missing := newBillingModeGlobalSecondaryIndexUpdates(desired, latest); len(missing) > 0 {
+						return nil, ackerr.NewTerminalError(fmt.Errorf(
+							"cannot switch to PROVISIONED billing mode, global secondary indexes without "+
+								"read and write capacity units: %s", strings.Join(missing, ", "),
+						))
+					}
+				}
+				if err := rm.syncTable(ctx, desired, latest, delta); err != nil {
+					return nil, fmt.Errorf("cannot update table %v", err)
+				}
+				return nil, nil
+			},
+		})
 	}
 
 	if delta.DifferentAt("Spec.ContinuousBackups") {
-		err = rm.syncContinuousBackup(ctx, desired)
-		if err != nil {
-			return nil, fmt.Errorf("cannot update table %v", err)
-		}
+		updates = append(updates, &tableUpdate{
+			ops: []*v1alpha1.PlannedOperation{approval.NewPlannedOperation(
+				"UpdateContinuousBackups", approval.ActionUpdatePointInTimeRecovery,
+				describeChange("ContinuousBackups", l.ContinuousBackups, d.ContinuousBackups),
+			)},
+			apply: func(ctx context.Context) (*resource, error) {
+				if err := rm.syncContinuousBackup(ctx, desired); err != nil {
+					return nil, fmt.Errorf("cannot update table %v", err)
+				}
+				return nil, nil
+			},
+		})
 	}
 
+	if !delta.DifferentExcept("Spec.Tags", "Spec.TimeToLive") {
+		return updates
+	}
 	// We want to update fast fields first
 	// Then attributes
 	// then GSI
-	if delta.DifferentExcept("Spec.Tags", "Spec.TimeToLive") {
-		switch {
-		case delta.DifferentAt("Spec.StreamSpecification"):
-			if err := rm.syncTable(ctx, desired, delta); err != nil {
-				return nil, err
-			}
-		case delta.DifferentAt("Spec.ProvisionedThroughput"):
-			if err := rm.syncTableProvisionedThroughput(ctx, desired); err != nil {
-				return nil, err
-			}
-		case delta.DifferentAt("Spec.GlobalSecondaryIndexes") && delta.DifferentAt("Spec.AttributeDefinitions"):
-			if err := rm.syncTableGlobalSecondaryIndexes(ctx, latest, desired); err != nil {
-				if awsErr, ok := ackerr.AWSError(err); ok &&
-					awsErr.Code() == "LimitExceededException" {
-					return nil, requeueWaitGSIReady
+	// One of the following steps is made per reconciliation, as the table is
+	// updating until it is done.
+	if delta.DifferentAt("Spec.StreamSpecification") {
+		var ops []*v1alpha1.PlannedOperation
+		if needsStreamTransition(desired, latest) {
+			ops = append(ops, approval.NewPlannedOperation(
+				"UpdateTable", approval.ActionUpdateStreamSpecification,
+				"disable stream before enabling it with its new view type",
+			))
+		}
+		ops = append(ops, approval.NewPlannedOperation(
+			"UpdateTable", approval.ActionUpdateStreamSpecification,
+			describeChange("StreamSpecification", l.StreamSpecification, d.StreamSpecification),
+		))
+		updates = append(updates, &tableUpdate{
+			ops: ops,
+			apply: func(ctx context.Context) (*resource, error) {
+				if err := rm.syncStreamSpecification(ctx, ko, desired, latest, delta); err != nil {
+					return nil, err
 				}
-				return nil, err
-			}
-		}
+				return &resource{ko}, requeueWaitWhileUpdating
+			},
+		})
+	}
+	if delta.DifferentAt("Spec.ProvisionedThroughput") {
+		updates = append(updates, &tableUpdate{
+			ops: []*v1alpha1.PlannedOperation{approval.NewPlannedOperation(
+				"UpdateTable", approval.ActionUpdateProvisionedThroughput,
+				describeChange("ProvisionedThroughput", l.ProvisionedThroughput, d.ProvisionedThroughput),
+			)},
+			apply: func(ctx context.Context) (*resource, error) {
+				if err := rm.syncTableProvisionedThroughput(ctx, desired); err != nil {
+					return nil, err
+				}
+				return &resource{ko}, requeueWaitWhileUpdating
+			},
+		})
+	}
+	if delta.DifferentAt("Spec.GlobalSecondaryIndexes") {
+		var ops []*v1alpha1.PlannedOperation
+		added, updated, removed := computeGlobalSecondaryIndexDelta(
+			l.GlobalSecondaryIndexes,
+			d.GlobalSecondaryIndexes,
+		)
+		for _, gsi := range added {
+			ops = append(ops, approval.NewPlannedOperation(
+				"UpdateTable", approval.ActionCreateGlobalSecondaryIndex,
+				"create global secondary index "+aws.StringValue(gsi.IndexName),
+			))
+		}
+		for _, gsi := range updated {
+			ops = append(ops, approval.NewPlannedOperation(
+				"UpdateTable", approval.ActionUpdateGlobalSecondaryIndex,
+				"update global secondary index "+aws.StringValue(gsi.IndexName)+" "+
+					describeChange("ProvisionedThroughput", nil, gsi.ProvisionedThroughput),
+			))
+		}
+		for _, name := range removed {
+			ops = append(ops, approval.NewPlannedOperation(
+				"UpdateTable", approval.ActionDeleteGlobalSecondaryIndex,
+				"delete global secondary index "+name,
+			))
+		}
+		updates = append(updates, &tableUpdate{
+			ops: ops,
+			apply: func(ctx context.Context) (*resource, error) {
+				if err := rm.syncTableGlobalSecondaryIndexes(ctx, latest, desired); err != nil {
+					return nil, err
+				}
+				return &resource{ko}, requeueWaitWhileUpdating
+			},
+		})
 	}
+	return updates
+}
 
-	return &resource{ko}, requeueWaitWhileUpdating
+// applyTableUpdates makes the supplied steps of the update of a table in
+// order. It returns the table and the error of the first step that must be
+// returned to the reconciler, nil once all the steps are made.
+func (rm *resourceManager) applyTableUpdates(
+	ctx context.Context,
+	updates []*tableUpdate,
+) (*resource, error) {
+	for _, update := range updates {
+		if updated, err := update.apply(ctx); updated != nil || err != nil {
+			return updated, err
+		}
+	}
+	return nil, nil
 }
 
 // syncTable updates a given table billing mode, stream specification
@@ -242,13 +545,14 @@ func (rm *resourceManager) customUpdateTable(
 func (rm *resourceManager) syncTable(
 	ctx context.Context,
 	r *resource,
+	latest *resource,
 	delta *ackcompare.Delta,
 ) (err error) {
 	rlog := ackrtlog.FromContext(ctx)
 	exit := rlog.Trace("rm.syncTable")
 	defer exit(err)
 
-	input, err := rm.newUpdateTablePayload(ctx, r, delta)
+	input, err := rm.newUpdateTablePayload(ctx, r, latest, delta)
 	if err != nil {
 		return err
 	}
@@ -264,10 +568,11 @@ func (rm *resourceManager) syncTable(
 func (rm *resourceManager) newUpdateTablePayload(
 	ctx context.Context,
 	r *resource,
+	latest *resource,
 	delta *ackcompare.Delta,
 ) (*svcsdk.UpdateTableInput, error) {
 	input := &svcsdk.UpdateTableInput{
-		TableName: aws.String(*r.ko.Spec.TableName),
+		TableName: physicalTableName(r.ko),
 	}
 
 	if delta.DifferentAt("Spec.BillingMode") {
@@ -296,6 +601,9 @@ func (rm *resourceManager) newUpdateTablePayload(
 					input.ProvisionedThroughput.WriteCapacityUnits = aws.Int64(0)
 				}
 			}
+			// DynamoDB requires the throughput of every existing global
+			// secondary index when switching to provisioned capacity.
+			input.GlobalSecondaryIndexUpdates, _ = newBillingModeGlobalSecondaryIndexUpdates(r, latest)
 		}
 	}
 	if delta.DifferentAt("Spec.StreamSpecification") {
@@ -337,7 +645,7 @@ func (rm *resourceManager) syncTableSSESpecification(
 	defer exit(err)
 
 	input := &svcsdk.UpdateTableInput{
-		TableName: aws.String(*r.ko.Spec.TableName),
+		TableName: physicalTableName(r.ko),
 	}
 	if r.ko.Spec.SSESpecification != nil {
 		input.SSESpecification = &svcsdk.SSESpecification{}
@@ -378,7 +686,7 @@ func (rm *resourceManager) syncTableProvisionedThroughput(
 	defer exit(err)
 
 	input := &svcsdk.UpdateTableInput{
-		TableName:             aws.String(*r.ko.Spec.TableName),
+		TableName:             physicalTableName(r.ko),
 		ProvisionedThroughput: &svcsdk.ProvisionedThroughput{},
 	}
 	if r.ko.Spec.ProvisionedThroughput != nil {
@@ -411,7 +719,8 @@ func (rm *resourceManager) syncTableProvisionedThroughput(
 }
 
 // setResourceAdditionalFields will describe the fields that are not return by
-// DescribeTable calls
+// DescribeTable calls. The tags, TTL and PITR specifications are read in
+// parallel, and served from the additional fields cache when possible.
 func (rm *resourceManager) setResourceAdditionalFields(
 	ctx context.Context,
 	ko *v1alpha1.Table,
@@ -419,25 +728,44 @@ func (rm *resourceManager) setResourceAdditionalFields(
 	rlog := ackrtlog.FromContext(ctx)
 	exit := rlog.Trace("rm.setResourceAdditionalFields")
 	defer func(err error) { exit(err) }(err)
-
-	if tags, err := rm.getResourceTagsPagesWithContext(ctx, string(*ko.Status.ACKResourceMetadata.ARN)); err != nil {
-		return err
-	} else {
-		ko.Spec.Tags = tags
-	}
-
-	if ttlSpec, err := rm.getResourceTTLWithContext(ctx, ko.Spec.TableName); err != nil {
-		return err
-	} else {
-		ko.Spec.TimeToLive = ttlSpec
-	}
-
-	if pitrSpec, err := rm.getResourcePointInTimeRecoveryWithContext(ctx, ko.Spec.TableName); err != nil {
-		return err
-	} else {
-		ko.Spec.ContinuousBackups = pitrSpec
+	defer func() {
+		err = requeueOnThrottle(&resource{ko}, err)
+	}()
+
+	arn := string(*ko.Status.ACKResourceMetadata.ARN)
+	var (
+		wg       sync.WaitGroup
+		tags     []*v1alpha1.Tag
+		ttlSpec  *v1alpha1.TimeToLiveSpecification
+		pitrSpec *v1alpha1.PointInTimeRecoverySpecification
+		tagsErr  error
+		ttlErr   error
+		pitrErr  error
+	)
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		tags, tagsErr = rm.getCachedResourceTags(ctx, arn)
+	}()
+	go func() {
+		defer wg.Done()
+		ttlSpec, ttlErr = rm.getCachedResourceTTL(ctx, arn, physicalTableName(ko))
+	}()
+	go func() {
+		defer wg.Done()
+		pitrSpec, pitrErr = rm.getCachedResourcePointInTimeRecovery(ctx, arn, physicalTableName(ko))
+	}()
+	wg.Wait()
+
+	for _, err := range []error{tagsErr, ttlErr, pitrErr} {
+		if err != nil {
+			return err
+		}
 	}
-
+	ko.Spec.Tags = tags
+	ko.Spec.TimeToLive = ttlSpec
+	ko.Spec.ContinuousBackups = pitrSpec
+	observeTableAdditionalFieldsMetrics(ko)
 	return nil
 }
 
@@ -528,42 +856,23 @@ func customPreCompare(
 		}
 	}
 
-	if a.ko.Spec.BillingMode == nil {
-		a.ko.Spec.BillingMode = aws.String(string(v1alpha1.BillingMode_PROVISIONED))
-	}
-	if a.ko.Spec.TableClass == nil {
-		a.ko.Spec.TableClass = aws.String(string(v1alpha1.TableClass_STANDARD))
-	}
-	// See https://github.com/aws-controllers-k8s/community/issues/1595
-	if aws.StringValue(a.ko.Spec.BillingMode) == string(v1alpha1.BillingMode_PAY_PER_REQUEST) {
-		a.ko.Spec.ProvisionedThroughput = nil
-	}
-	if aws.StringValue(b.ko.Spec.BillingMode) == string(v1alpha1.BillingMode_PAY_PER_REQUEST) {
-		b.ko.Spec.ProvisionedThroughput = nil
-	}
-
 	if len(a.ko.Spec.Tags) != len(b.ko.Spec.Tags) {
 		delta.Add("Spec.Tags", a.ko.Spec.Tags, b.ko.Spec.Tags)
 	} else if a.ko.Spec.Tags != nil && b.ko.Spec.Tags != nil {
-		if !equalTags(a.ko.Spec.Tags, b.ko.Spec.Tags) {
+		if !tags.Equal(a.ko.Spec.Tags, b.ko.Spec.Tags) {
 			delta.Add("Spec.Tags", a.ko.Spec.Tags, b.ko.Spec.Tags)
 		}
 	}
-	if a.ko.Spec.TimeToLive == nil && b.ko.Spec.TimeToLive != nil {
-		a.ko.Spec.TimeToLive = &v1alpha1.TimeToLiveSpecification{
-			Enabled: &DefaultTTLEnabledValue,
-		}
-	}
-	if a.ko.Spec.ContinuousBackups == nil && b.ko.Spec.ContinuousBackups != nil &&
-		b.ko.Spec.ContinuousBackups.PointInTimeRecoveryEnabled != nil {
-		a.ko.Spec.ContinuousBackups = &v1alpha1.PointInTimeRecoverySpecification{
-			PointInTimeRecoveryEnabled: &DefaultPITREnabledValue,
-		}
-	}
 }
 
 // equalAttributeDefinitions return whether two AttributeDefinition arrays are equal or not.
 func equalAttributeDefinitions(a, b []*v1alpha1.AttributeDefinition) bool {
+	return containsAttributeDefinitions(a, b) && containsAttributeDefinitions(b, a)
+}
+
+// containsAttributeDefinitions returns whether every AttributeDefinition of a
+// is defined with the same type in b.
+func containsAttributeDefinitions(a, b []*v1alpha1.AttributeDefinition) bool {
 	for _, aElement := range a {
 		found := false
 		for _, bElement := range b {
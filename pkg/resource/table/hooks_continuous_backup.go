@@ -31,6 +31,8 @@ func (rm *resourceManager) syncContinuousBackup(
 	exit := rlog.Trace("rm.syncContinuousBackup")
 	defer func(err error) { exit(err) }(err)
 
+	defer additionalFields.invalidate(tableARN(desired), cachedFieldPITR)
+
 	pitrSpec := &svcsdk.PointInTimeRecoverySpecification{}
 	if desired.ko.Spec.ContinuousBackups != nil &&
 		desired.ko.Spec.ContinuousBackups.PointInTimeRecoveryEnabled != nil {
@@ -42,7 +44,7 @@ func (rm *resourceManager) syncContinuousBackup(
 	_, err = rm.sdkapi.UpdateContinuousBackupsWithContext(
 		ctx,
 		&svcsdk.UpdateContinuousBackupsInput{
-			TableName:                        desired.ko.Spec.TableName,
+			TableName:                        physicalTableName(desired.ko),
 			PointInTimeRecoverySpecification: pitrSpec,
 		},
 	)
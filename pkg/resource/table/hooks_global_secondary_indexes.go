@@ -179,8 +179,7 @@ func (rm *resourceManager) newUpdateTableGlobalSecondaryIndexUpdatesPayload(
 		desired.ko.Spec.GlobalSecondaryIndexes,
 	)
 	input = &svcsdk.UpdateTableInput{
-		TableName:            aws.String(*latest.ko.Spec.TableName),
-		AttributeDefinitions: newSDKAttributesDefinition(desired.ko.Spec.AttributeDefinitions),
+		TableName: physicalTableName(latest.ko),
 	}
 
 	// If we know that we're still gonna need to update another GSI we return a value gt 0.
@@ -199,6 +198,7 @@ func (rm *resourceManager) newUpdateTableGlobalSecondaryIndexUpdatesPayload(
 			},
 		}
 		input.GlobalSecondaryIndexUpdates = append(input.GlobalSecondaryIndexUpdates, update)
+		input.AttributeDefinitions = newGlobalSecondaryIndexUpdateAttributeDefinitions(desired, latest, addedGSI, nil)
 		// We can only remove, update or add one GSI at once. Hence we return the update call input
 		// after we find the first added GSI.
 		return input, gsisInQueue, nil
@@ -212,6 +212,7 @@ func (rm *resourceManager) newUpdateTableGlobalSecondaryIndexUpdatesPayload(
 			},
 		}
 		input.GlobalSecondaryIndexUpdates = append(input.GlobalSecondaryIndexUpdates, update)
+		input.AttributeDefinitions = newGlobalSecondaryIndexUpdateAttributeDefinitions(desired, latest, nil, nil)
 		// We can only remove, update or add one GSI at once. Hence we return the update call input
 		// after we find the first updated GSI.
 		return input, gsisInQueue, nil
@@ -224,6 +225,7 @@ func (rm *resourceManager) newUpdateTableGlobalSecondaryIndexUpdatesPayload(
 			},
 		}
 		input.GlobalSecondaryIndexUpdates = append(input.GlobalSecondaryIndexUpdates, update)
+		input.AttributeDefinitions = newGlobalSecondaryIndexUpdateAttributeDefinitions(desired, latest, nil, &removedGSI)
 		// We can only remove, update or add one GSI at once. Hence we return the update call input
 		// after we find the first removed GSI.
 		return input, gsisInQueue, nil
@@ -231,6 +233,37 @@ func (rm *resourceManager) newUpdateTableGlobalSecondaryIndexUpdatesPayload(
 	return input, gsisInQueue, nil
 }
 
+// newBillingModeGlobalSecondaryIndexUpdates returns the provisioned throughput
+// updates of the existing global secondary indexes of a table switching to the
+// PROVISIONED billing mode, which DynamoDB requires in the same call. The
+// throughputs are read from the desired spec, the names of the existing
+// indexes without desired read and write capacity units are returned in
+// missing.
+func newBillingModeGlobalSecondaryIndexUpdates(
+	desired *resource,
+	latest *resource,
+) (updates []*svcsdk.GlobalSecondaryIndexUpdate, missing []string) {
+	for _, latestGSI := range latest.ko.Spec.GlobalSecondaryIndexes {
+		if latestGSI == nil {
+			continue
+		}
+		gsi := findGlobalSecondaryIndex(desired.ko.Spec.GlobalSecondaryIndexes, latestGSI.IndexName)
+		if gsi == nil || gsi.ProvisionedThroughput == nil ||
+			gsi.ProvisionedThroughput.ReadCapacityUnits == nil ||
+			gsi.ProvisionedThroughput.WriteCapacityUnits == nil {
+			missing = append(missing, aws.StringValue(latestGSI.IndexName))
+			continue
+		}
+		updates = append(updates, &svcsdk.GlobalSecondaryIndexUpdate{
+			Update: &svcsdk.UpdateGlobalSecondaryIndexAction{
+				IndexName:             aws.String(*gsi.IndexName),
+				ProvisionedThroughput: newSDKProvisionedThroughput(gsi.ProvisionedThroughput),
+			},
+		})
+	}
+	return updates, missing
+}
+
 // newSDKProvisionedThroughput builds a new *svcsdk.ProvisionedThroughput
 func newSDKProvisionedThroughput(pt *v1alpha1.ProvisionedThroughput) *svcsdk.ProvisionedThroughput {
 	provisionedThroughput := &svcsdk.ProvisionedThroughput{}
@@ -17,10 +17,10 @@ import (
 	"context"
 
 	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
-	ackutil "github.com/aws-controllers-k8s/runtime/pkg/util"
 	svcsdk "github.com/aws/aws-sdk-go/service/dynamodb"
 
 	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/tags"
 )
 
 // syncTableTags updates a dynamodb table tags.
@@ -36,7 +36,9 @@ func (rm *resourceManager) syncTableTags(
 	exit := rlog.Trace("rm.syncTableTags")
 	defer exit(err)
 
-	added, removed := computeTagsDelta(latest.ko.Spec.Tags, desired.ko.Spec.Tags)
+	defer additionalFields.invalidate(tableARN(latest), cachedFieldTags)
+
+	added, removed := tags.ComputeDelta(latest.ko.Spec.Tags, desired.ko.Spec.Tags)
 
 	// There are no API calls to update an existing tag. To update a tag we will have to first
 	// delete it and then recreate it with the new value.
@@ -49,7 +51,7 @@ func (rm *resourceManager) syncTableTags(
 				TagKeys:     removed,
 			},
 		)
-		rm.metrics.RecordAPICall("GET", "UntagResource", err)
+		rm.metrics.RecordAPICall("UPDATE", "UntagResource", err)
 		if err != nil {
 			return err
 		}
@@ -60,10 +62,10 @@ func (rm *resourceManager) syncTableTags(
 			ctx,
 			&svcsdk.TagResourceInput{
 				ResourceArn: (*string)(latest.ko.Status.ACKResourceMetadata.ARN),
-				Tags:        sdkTagsFromResourceTags(added),
+				Tags:        tags.ToSDK(added),
 			},
 		)
-		rm.metrics.RecordAPICall("GET", "UntagResource", err)
+		rm.metrics.RecordAPICall("UPDATE", "TagResource", err)
 		if err != nil {
 			return err
 		}
@@ -71,69 +73,6 @@ func (rm *resourceManager) syncTableTags(
 	return nil
 }
 
-// equalTags returns true if two Tag arrays are equal regardless of the order
-// of their elements.
-func equalTags(
-	a []*v1alpha1.Tag,
-	b []*v1alpha1.Tag,
-) bool {
-	added, removed := computeTagsDelta(a, b)
-	return len(added) == 0 && len(removed) == 0
-}
-
-// resourceTagsFromSDKTags transforms a *svcsdk.Tag array to a *v1alpha1.Tag array.
-func resourceTagsFromSDKTags(svcTags []*svcsdk.Tag) []*v1alpha1.Tag {
-	tags := make([]*v1alpha1.Tag, len(svcTags))
-	for i := range svcTags {
-		tags[i] = &v1alpha1.Tag{
-			Key:   svcTags[i].Key,
-			Value: svcTags[i].Value,
-		}
-	}
-	return tags
-}
-
-// svcTagsFromResourceTags transforms a *v1alpha1.Tag array to a *svcsdk.Tag array.
-func sdkTagsFromResourceTags(rTags []*v1alpha1.Tag) []*svcsdk.Tag {
-	tags := make([]*svcsdk.Tag, len(rTags))
-	for i := range rTags {
-		tags[i] = &svcsdk.Tag{
-			Key:   rTags[i].Key,
-			Value: rTags[i].Value,
-		}
-	}
-	return tags
-}
-
-// computeTagsDelta compares two Tag arrays and return three different list
-// containing the added, updated and removed tags.
-// The removed tags only contains the Key of tags
-func computeTagsDelta(
-	a []*v1alpha1.Tag,
-	b []*v1alpha1.Tag,
-) (added []*v1alpha1.Tag, removed []*string) {
-	var visitedIndexes []string
-mainLoop:
-	for _, aElement := range b {
-		visitedIndexes = append(visitedIndexes, *aElement.Key)
-		for _, bElement := range a {
-			if equalStrings(aElement.Key, bElement.Key) {
-				if !equalStrings(aElement.Value, bElement.Value) {
-					added = append(added, bElement)
-				}
-				continue mainLoop
-			}
-		}
-		removed = append(removed, aElement.Key)
-	}
-	for _, bElement := range a {
-		if !ackutil.InStrings(*bElement.Key, visitedIndexes) {
-			added = append(added, bElement)
-		}
-	}
-	return added, removed
-}
-
 // getResourceTagsPagesWithContext queries the list of tags of a given resource.
 func (rm *resourceManager) getResourceTagsPagesWithContext(ctx context.Context, resourceARN string) ([]*v1alpha1.Tag, error) {
 	var err error
@@ -141,7 +80,7 @@ func (rm *resourceManager) getResourceTagsPagesWithContext(ctx context.Context,
 	exit := rlog.Trace("rm.getResourceTagsPagesWithContext")
 	defer exit(err)
 
-	tags := []*v1alpha1.Tag{}
+	resourceTags := []*v1alpha1.Tag{}
 
 	var token *string = nil
 	for {
@@ -157,11 +96,11 @@ func (rm *resourceManager) getResourceTagsPagesWithContext(ctx context.Context,
 		if err != nil {
 			return nil, err
 		}
-		tags = append(tags, resourceTagsFromSDKTags(listTagsOfResourceOutput.Tags)...)
+		resourceTags = append(resourceTags, tags.FromSDK(listTagsOfResourceOutput.Tags)...)
 		if listTagsOfResourceOutput.NextToken == nil {
 			break
 		}
 		token = listTagsOfResourceOutput.NextToken
 	}
-	return tags, nil
+	return resourceTags, nil
 }
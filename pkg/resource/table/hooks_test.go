@@ -14,7 +14,7 @@
 package table
 
 import (
-	"reflect"
+	"context"
 	"testing"
 
 	"github.com/aws-controllers-k8s/runtime/pkg/compare"
@@ -43,88 +43,8 @@ var (
 	}
 )
 
-func Test_computeTagsDelta(t *testing.T) {
-	type args struct {
-		a []*v1alpha1.Tag
-		b []*v1alpha1.Tag
-	}
-	tests := []struct {
-		name        string
-		args        args
-		wantAdded   []*v1alpha1.Tag
-		wantRemoved []*string
-	}{
-		{
-			name: "nil arrays",
-			args: args{
-				a: nil,
-				b: nil,
-			},
-			wantAdded:   nil,
-			wantRemoved: nil,
-		},
-		{
-			name: "empty arrays",
-			args: args{
-				a: []*v1alpha1.Tag{},
-				b: []*v1alpha1.Tag{},
-			},
-			wantAdded:   nil,
-			wantRemoved: nil,
-		},
-		{
-			name: "added tags",
-			args: args{
-				a: []*v1alpha1.Tag{Tag1, Tag2},
-				b: []*v1alpha1.Tag{},
-			},
-			wantAdded:   []*v1alpha1.Tag{Tag1, Tag2},
-			wantRemoved: nil,
-		},
-		{
-			name: "removed tags",
-			args: args{
-				a: nil,
-				b: []*v1alpha1.Tag{Tag1, Tag2},
-			},
-			wantAdded:   nil,
-			wantRemoved: []*string{aws.String("k1"), aws.String("k2")},
-		},
-		{
-			name: "updated tags",
-			args: args{
-				a: []*v1alpha1.Tag{Tag1, Tag2Updated},
-				b: []*v1alpha1.Tag{Tag1, Tag2},
-			},
-			wantAdded:   []*v1alpha1.Tag{Tag2Updated},
-			wantRemoved: nil,
-		},
-		{
-			name: "added, updated and removed tags",
-			args: args{
-				a: []*v1alpha1.Tag{Tag2Updated, Tag3},
-				// remove Tag1, update Tag2 and add Tag3
-				b: []*v1alpha1.Tag{Tag1, Tag2},
-			},
-			wantAdded:   []*v1alpha1.Tag{Tag2Updated, Tag3},
-			wantRemoved: []*string{aws.String("k1")},
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			gotAdded, gotRemoved := computeTagsDelta(tt.args.a, tt.args.b)
-			if !reflect.DeepEqual(gotAdded, tt.wantAdded) {
-				t.Errorf("computeTagsDelta() gotAdded = %v, want %v", gotAdded, tt.wantAdded)
-			}
-			if !reflect.DeepEqual(gotRemoved, tt.wantRemoved) {
-				t.Errorf("computeTagsDelta() gotRemoved = %v, want %v", gotRemoved, tt.wantRemoved)
-			}
-		})
-	}
-}
-
 func Test_customPreCompare(t *testing.T) {
-	t.Run("when billing mode is PAY_PER_REQUEST, ProvisionedThroughput should be nil", func(t *testing.T) {
+	t.Run("when billing mode is PAY_PER_REQUEST, ProvisionedThroughput should be ignored", func(t *testing.T) {
 		a := &resource{ko: &v1alpha1.Table{
 			Spec: v1alpha1.TableSpec{
 				BillingMode:           aws.String(string(v1alpha1.BillingMode_PAY_PER_REQUEST)),
@@ -134,21 +54,41 @@ func Test_customPreCompare(t *testing.T) {
 
 		b := &resource{ko: &v1alpha1.Table{
 			Spec: v1alpha1.TableSpec{
-				BillingMode:           aws.String(string(v1alpha1.BillingMode_PAY_PER_REQUEST)),
-				ProvisionedThroughput: &v1alpha1.ProvisionedThroughput{},
+				BillingMode: aws.String(string(v1alpha1.BillingMode_PAY_PER_REQUEST)),
+				ProvisionedThroughput: &v1alpha1.ProvisionedThroughput{
+					ReadCapacityUnits:  aws.Int64(0),
+					WriteCapacityUnits: aws.Int64(0),
+				},
 			},
 		}}
-		delta := &compare.Delta{}
-		customPreCompare(delta, a, b)
-		if a.ko.Spec.ProvisionedThroughput != nil {
-			t.Errorf("a.Spec.ProvisionedThroughput should be nil, but got %+v", a.ko.Spec.ProvisionedThroughput)
+		delta := newResourceDelta(a, b)
+		if delta.DifferentAt("Spec.ProvisionedThroughput") {
+			t.Errorf("Spec.ProvisionedThroughput should not be different, but got %+v", delta.Differences)
 		}
-
-		if b.ko.Spec.ProvisionedThroughput != nil {
-			t.Errorf("b.Spec.ProvisionedThroughput should be nil, but got %+v", a.ko.Spec.ProvisionedThroughput)
+		// The compared resources must not be modified
+		if a.ko.Spec.ProvisionedThroughput == nil || a.ko.Spec.TableClass != nil {
+			t.Errorf("a.Spec should not be modified, but got %+v", a.ko.Spec)
 		}
 	})
 
+	t.Run("unset fields should be equal to their default values", func(t *testing.T) {
+		a := &resource{ko: &v1alpha1.Table{}}
+		b := &resource{ko: &v1alpha1.Table{
+			Spec: v1alpha1.TableSpec{
+				BillingMode: aws.String(string(v1alpha1.BillingMode_PROVISIONED)),
+				TableClass:  aws.String(string(v1alpha1.TableClass_STANDARD)),
+				TimeToLive: &v1alpha1.TimeToLiveSpecification{
+					Enabled: aws.Bool(false),
+				},
+				ContinuousBackups: &v1alpha1.PointInTimeRecoverySpecification{
+					PointInTimeRecoveryEnabled: aws.Bool(false),
+				},
+			},
+		}}
+		delta := newResourceDelta(a, b)
+		require.Empty(t, delta.Differences)
+	})
+
 	t.Run("GSI ProvisionedThroughput should be equal when nil and 0 capacity", func(t *testing.T) {
 		a := &resource{ko: &v1alpha1.Table{
 			Spec: v1alpha1.TableSpec{
@@ -505,3 +445,66 @@ func Test_newResourceDelta_customDeltaFunction_AttributeDefinitions(t *testing.T
 		})
 	}
 }
+
+func Test_newUpdateTablePayload_BillingModeProvisioned(t *testing.T) {
+	gsi := func(name string, pt *v1alpha1.ProvisionedThroughput) *v1alpha1.GlobalSecondaryIndex {
+		return &v1alpha1.GlobalSecondaryIndex{
+			IndexName:             aws.String(name),
+			ProvisionedThroughput: pt,
+		}
+	}
+	throughput := &v1alpha1.ProvisionedThroughput{
+		ReadCapacityUnits:  aws.Int64(5),
+		WriteCapacityUnits: aws.Int64(10),
+	}
+	latest := &resource{ko: &v1alpha1.Table{Spec: v1alpha1.TableSpec{
+		TableName:   aws.String("t"),
+		BillingMode: aws.String(string(v1alpha1.BillingMode_PAY_PER_REQUEST)),
+		GlobalSecondaryIndexes: []*v1alpha1.GlobalSecondaryIndex{
+			gsi("idx1", nil),
+			gsi("idx2", nil),
+		},
+	}}}
+	desired := &resource{ko: latest.ko.DeepCopy()}
+	desired.ko.Spec.BillingMode = aws.String(string(v1alpha1.BillingMode_PROVISIONED))
+	desired.ko.Spec.ProvisionedThroughput = throughput
+	desired.ko.Spec.GlobalSecondaryIndexes = []*v1alpha1.GlobalSecondaryIndex{
+		gsi("idx1", throughput),
+		gsi("idx2", throughput),
+		// Indexes to create are not updated.
+		gsi("idx3", throughput),
+	}
+	delta := compare.NewDelta()
+	delta.Add("Spec.BillingMode", desired.ko.Spec.BillingMode, latest.ko.Spec.BillingMode)
+
+	input, err := (&resourceManager{}).newUpdateTablePayload(context.TODO(), desired, latest, delta)
+	require.NoError(t, err)
+	require.Equal(t, "PROVISIONED", *input.BillingMode)
+	require.Len(t, input.GlobalSecondaryIndexUpdates, 2)
+	for i, name := range []string{"idx1", "idx2"} {
+		update := input.GlobalSecondaryIndexUpdates[i].Update
+		require.Equal(t, name, *update.IndexName)
+		require.Equal(t, int64(5), *update.ProvisionedThroughput.ReadCapacityUnits)
+		require.Equal(t, int64(10), *update.ProvisionedThroughput.WriteCapacityUnits)
+	}
+
+	desired.ko.Spec.GlobalSecondaryIndexes[1].ProvisionedThroughput = nil
+	_, missing := newBillingModeGlobalSecondaryIndexUpdates(desired, latest)
+	require.Equal(t, []string{"idx2"}, missing)
+}
+
+func Test_isTTLEnabled(t *testing.T) {
+	spec := &v1alpha1.TableSpec{}
+	require.False(t, isTTLEnabled(spec.TimeToLive))
+
+	// The defaulted spec of a table without TTL doesn't need a TTL update
+	// once the table is created.
+	defaultTableSpec(spec)
+	require.False(t, isTTLEnabled(spec.TimeToLive))
+
+	spec.TimeToLive = &v1alpha1.TimeToLiveSpecification{
+		AttributeName: aws.String("expiresAt"),
+		Enabled:       aws.Bool(true),
+	}
+	require.True(t, isTTLEnabled(spec.TimeToLive))
+}
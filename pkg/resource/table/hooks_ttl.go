@@ -17,6 +17,7 @@ import (
 	"context"
 
 	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go/aws"
 	svcsdk "github.com/aws/aws-sdk-go/service/dynamodb"
 
 	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
@@ -32,6 +33,8 @@ func (rm *resourceManager) syncTTL(
 	exit := rlog.Trace("rm.syncTTL")
 	defer func(err error) { exit(err) }(err)
 
+	defer additionalFields.invalidate(tableARN(latest), cachedFieldTTL)
+
 	ttlSpec := &svcsdk.TimeToLiveSpecification{}
 	if desired.ko.Spec.TimeToLive != nil {
 		ttlSpec.AttributeName = desired.ko.Spec.TimeToLive.AttributeName
@@ -54,7 +57,7 @@ func (rm *resourceManager) syncTTL(
 	_, err = rm.sdkapi.UpdateTimeToLiveWithContext(
 		ctx,
 		&svcsdk.UpdateTimeToLiveInput{
-			TableName:               desired.ko.Spec.TableName,
+			TableName:               physicalTableName(desired.ko),
 			TimeToLiveSpecification: ttlSpec,
 		},
 	)
@@ -89,3 +92,32 @@ func (rm *resourceManager) getResourceTTLWithContext(ctx context.Context, tableN
 		Enabled:       &isEnabled,
 	}, nil
 }
+
+// getResourceTTLStatusWithContext queries the table TTL status of a given
+// resource, bypassing the cache.
+func (rm *resourceManager) getResourceTTLStatusWithContext(ctx context.Context, tableName *string) (string, error) {
+	var err error
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.getResourceTTLStatusWithContext")
+	defer func(err error) { exit(err) }(err)
+
+	res, err := rm.sdkapi.DescribeTimeToLiveWithContext(
+		ctx,
+		&svcsdk.DescribeTimeToLiveInput{
+			TableName: tableName,
+		},
+	)
+	rm.metrics.RecordAPICall("GET", "DescribeTimeToLive", err)
+	if err != nil {
+		return "", err
+	}
+	return *res.TimeToLiveDescription.TimeToLiveStatus, nil
+}
+
+// isTTLEnabled returns true if the supplied TimeToLive specification enables
+// TTL. DynamoDB creates tables with TTL disabled and rejects requests
+// disabling an already disabled TTL, so new tables only need their TTL
+// synced when it is enabled.
+func isTTLEnabled(ttl *v1alpha1.TimeToLiveSpecification) bool {
+	return ttl != nil && aws.BoolValue(ttl.Enabled)
+}
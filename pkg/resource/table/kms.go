@@ -0,0 +1,88 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+// keyGroupVersionKind identifies the Key resources of the ACK KMS
+// controller. They are read as unstructured objects, so the controller
+// doesn't depend on the KMS controller API.
+var keyGroupVersionKind = schema.GroupVersionKind{
+	Group:   "kms.services.k8s.aws",
+	Version: "v1alpha1",
+	Kind:    "Key",
+}
+
+// keyStateEnabled is the state of the KMS keys tables can be encrypted with.
+const keyStateEnabled = "Enabled"
+
+// newReferencedKey returns an empty ACK KMS Key resource.
+func newReferencedKey() *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(keyGroupVersionKind)
+	return obj
+}
+
+// isReferencedKeyEnabled returns true if the supplied ACK KMS Key resource
+// reports an enabled key.
+func isReferencedKeyEnabled(obj *unstructured.Unstructured) bool {
+	state, _, _ := unstructured.NestedString(obj.Object, "status", "keyState")
+	return state == keyStateEnabled
+}
+
+// newRequeueWaitKeyEnabled returns the error requeuing the creation of a
+// table until its referenced key is enabled.
+func newRequeueWaitKeyEnabled(namespace, name string) error {
+	return ackrequeue.NeededAfter(
+		fmt.Errorf("%w: Key %s/%s is not enabled", ackerr.ResourceReferenceNotSynced, namespace, name),
+		30*time.Second,
+	)
+}
+
+// checkReferencedKeyEnabled requeues the creation of the supplied table until
+// the ACK KMS Key it references is enabled, as tables can only be created
+// with enabled keys.
+func checkReferencedKeyEnabled(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *v1alpha1.Table,
+) error {
+	sse := ko.Spec.SSESpecification
+	if tableARN(&resource{ko}) != "" || sse == nil || sse.KMSMasterKeyRef == nil ||
+		sse.KMSMasterKeyRef.From == nil || sse.KMSMasterKeyRef.From.Name == nil {
+		return nil
+	}
+	name := *sse.KMSMasterKeyRef.From.Name
+	obj := newReferencedKey()
+	if err := apiReader.Get(ctx, types.NamespacedName{Namespace: ko.Namespace, Name: name}, obj); err != nil {
+		return err
+	}
+	if !isReferencedKeyEnabled(obj) {
+		return newRequeueWaitKeyEnabled(ko.Namespace, name)
+	}
+	return nil
+}
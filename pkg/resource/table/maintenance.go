@@ -0,0 +1,145 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/maintenance"
+)
+
+var ErrWaitingMaintenanceWindow = fmt.Errorf("changes are waiting for the maintenance window")
+
+// hasMaintenanceChanges returns true if the delta contains changes that put
+// load on the table, and are only made during its maintenance window.
+func hasMaintenanceChanges(delta *ackcompare.Delta, desired, latest *resource) bool {
+	return delta.DifferentAt("Spec.BillingMode") ||
+		delta.DifferentAt("Spec.TableClass") ||
+		hasGlobalSecondaryIndexChanges(delta, desired, latest)
+}
+
+// hasGlobalSecondaryIndexChanges returns true if global secondary indexes
+// are created or deleted. Throughput updates don't need to wait for the
+// maintenance window.
+func hasGlobalSecondaryIndexChanges(delta *ackcompare.Delta, desired, latest *resource) bool {
+	if !delta.DifferentAt("Spec.GlobalSecondaryIndexes") {
+		return false
+	}
+	added, _, removed := computeGlobalSecondaryIndexDelta(
+		latest.ko.Spec.GlobalSecondaryIndexes,
+		desired.ko.Spec.GlobalSecondaryIndexes,
+	)
+	return len(added) > 0 || len(removed) > 0
+}
+
+// resolveMaintenanceWindow sets the maintenance window of the supplied table,
+// when it has none, to the default maintenance window of its namespace read
+// with the supplied reader. It is called on the copy of the table with
+// resolved references, the default isn't written to the spec of the table.
+func resolveMaintenanceWindow(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *v1alpha1.Table,
+) error {
+	if ko.Spec.MaintenanceWindow != nil {
+		return nil
+	}
+	mw, err := maintenance.NamespaceDefault(ctx, apiReader, ko.Namespace)
+	if err != nil {
+		return err
+	}
+	ko.Spec.MaintenanceWindow = mw
+	return nil
+}
+
+// maintenanceWindow returns the maintenance window of the supplied table,
+// or nil if it has none.
+func maintenanceWindow(r *resource) (*maintenance.Window, error) {
+	mw := r.ko.Spec.MaintenanceWindow
+	if mw == nil {
+		return nil, nil
+	}
+	w, err := maintenance.NewWindow(mw)
+	if err != nil {
+		return nil, ackerr.NewTerminalError(fmt.Errorf("invalid maintenance window: %v", err))
+	}
+	return w, nil
+}
+
+// deferToMaintenanceWindow returns a copy of the desired table without the
+// changes waiting for the maintenance window, along with its delta with the
+// latest table and the opening time of the window. The opening time is zero
+// if no changes are waiting.
+func deferToMaintenanceWindow(
+	desired *resource,
+	latest *resource,
+	delta *ackcompare.Delta,
+) (*resource, *ackcompare.Delta, time.Time, error) {
+	if !hasMaintenanceChanges(delta, desired, latest) {
+		return desired, delta, time.Time{}, nil
+	}
+	w, err := maintenanceWindow(desired)
+	if err != nil || w == nil {
+		return desired, delta, time.Time{}, err
+	}
+	now := time.Now()
+	if w.IsOpen(now) {
+		return desired, delta, time.Time{}, nil
+	}
+	next := w.NextOpening(now)
+	if next.IsZero() {
+		return nil, nil, time.Time{}, ackerr.NewTerminalError(fmt.Errorf(
+			"maintenance window never opens",
+		))
+	}
+
+	// Keep the latest values of the deferred fields, so that they don't
+	// differ anymore.
+	ko := desired.ko.DeepCopy()
+	if delta.DifferentAt("Spec.BillingMode") {
+		// Throughput changes may only be valid with the new billing mode.
+		ko.Spec.BillingMode = latest.ko.Spec.BillingMode
+		ko.Spec.ProvisionedThroughput = latest.ko.Spec.ProvisionedThroughput
+	}
+	if delta.DifferentAt("Spec.TableClass") {
+		ko.Spec.TableClass = latest.ko.Spec.TableClass
+	}
+	if hasGlobalSecondaryIndexChanges(delta, desired, latest) {
+		ko.Spec.GlobalSecondaryIndexes = latest.ko.Spec.GlobalSecondaryIndexes
+		ko.Spec.AttributeDefinitions = latest.ko.Spec.AttributeDefinitions
+	}
+	deferred := &resource{ko}
+	return deferred, newResourceDelta(deferred, latest), next, nil
+}
+
+// setWaitingMaintenanceWindow reports in ko that changes are waiting for the
+// maintenance window opening at the supplied time, and returns the error
+// requeueing the resource at that time.
+func setWaitingMaintenanceWindow(ko *v1alpha1.Table, next time.Time) error {
+	ko.Status.NextMaintenanceWindow = &metav1.Time{Time: next}
+	msg := "changes are waiting for the maintenance window opening at " +
+		next.UTC().Format(time.RFC3339)
+	setSyncedCondition(&resource{ko}, corev1.ConditionFalse, &msg, nil)
+	return ackrequeue.NeededAfter(ErrWaitingMaintenanceWindow, time.Until(next))
+}
@@ -0,0 +1,114 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"context"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+func Test_deferToMaintenanceWindow(t *testing.T) {
+	desired, latest := newPlanTestResources()
+	desired.ko.Spec.TableClass = aws.String(string(v1alpha1.TableClass_STANDARD_INFREQUENT_ACCESS))
+
+	t.Run("no maintenance window", func(t *testing.T) {
+		delta := newResourceDelta(desired, latest)
+		r, d, next, err := deferToMaintenanceWindow(desired, latest, delta)
+		require.NoError(t, err)
+		require.True(t, next.IsZero())
+		require.Equal(t, desired, r)
+		require.Equal(t, delta, d)
+	})
+
+	t.Run("open maintenance window", func(t *testing.T) {
+		desired := &resource{ko: desired.ko.DeepCopy()}
+		desired.ko.Spec.MaintenanceWindow = &v1alpha1.MaintenanceWindow{
+			Schedule: aws.String("* * * * *"),
+		}
+		delta := newResourceDelta(desired, latest)
+		r, d, next, err := deferToMaintenanceWindow(desired, latest, delta)
+		require.NoError(t, err)
+		require.True(t, next.IsZero())
+		require.Equal(t, desired, r)
+		require.Equal(t, delta, d)
+	})
+
+	t.Run("closed maintenance window", func(t *testing.T) {
+		desired := &resource{ko: desired.ko.DeepCopy()}
+		desired.ko.Spec.MaintenanceWindow = &v1alpha1.MaintenanceWindow{
+			Schedule: aws.String("0 0 1 1 *"),
+			Duration: aws.String("1m"),
+		}
+		delta := newResourceDelta(desired, latest)
+		r, d, next, err := deferToMaintenanceWindow(desired, latest, delta)
+		require.NoError(t, err)
+		require.False(t, next.IsZero())
+		// Tags are updated right away, the billing mode and table class
+		// changes wait for the window.
+		require.True(t, d.DifferentAt("Spec.Tags"))
+		require.False(t, d.DifferentAt("Spec.BillingMode"))
+		require.False(t, d.DifferentAt("Spec.TableClass"))
+		require.Equal(t, latest.ko.Spec.BillingMode, r.ko.Spec.BillingMode)
+		// The desired resource is left untouched.
+		require.Equal(t, string(v1alpha1.BillingMode_PAY_PER_REQUEST), *desired.ko.Spec.BillingMode)
+
+		ko := desired.ko.DeepCopy()
+		require.Error(t, setWaitingMaintenanceWindow(ko, next))
+		require.True(t, ko.Status.NextMaintenanceWindow.Time.Equal(next))
+		cond := getConditionOfType(&resource{ko}, ackv1alpha1.ConditionTypeResourceSynced)
+		require.NotNil(t, cond)
+	})
+}
+
+func Test_resolveMaintenanceWindow(t *testing.T) {
+	reader := fake.NewClientBuilder().WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "plain"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name: "scheduled",
+			Annotations: map[string]string{
+				v1alpha1.MaintenanceWindowAnnotation: `{"schedule": "0 2 * * SUN", "duration": "2h"}`,
+			},
+		}},
+	).Build()
+	ctx := context.TODO()
+
+	ko := &v1alpha1.Table{ObjectMeta: metav1.ObjectMeta{Namespace: "plain"}}
+	require.NoError(t, resolveMaintenanceWindow(ctx, reader, ko))
+	require.Nil(t, ko.Spec.MaintenanceWindow)
+
+	ko = &v1alpha1.Table{ObjectMeta: metav1.ObjectMeta{Namespace: "scheduled"}}
+	require.NoError(t, resolveMaintenanceWindow(ctx, reader, ko))
+	require.Equal(t, &v1alpha1.MaintenanceWindow{
+		Schedule: aws.String("0 2 * * SUN"),
+		Duration: aws.String("2h"),
+	}, ko.Spec.MaintenanceWindow)
+
+	// The maintenance window of the table wins over the namespace default.
+	ko.Spec.MaintenanceWindow = &v1alpha1.MaintenanceWindow{Schedule: aws.String("* * * * *")}
+	require.NoError(t, resolveMaintenanceWindow(ctx, reader, ko))
+	require.Equal(t, "* * * * *", *ko.Spec.MaintenanceWindow.Schedule)
+
+	ko = &v1alpha1.Table{ObjectMeta: metav1.ObjectMeta{Namespace: "missing"}}
+	require.NoError(t, resolveMaintenanceWindow(ctx, reader, ko))
+	require.Nil(t, ko.Spec.MaintenanceWindow)
+}
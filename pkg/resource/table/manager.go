@@ -39,6 +39,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	svcapitypes "github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/throttle"
 )
 
 var (
@@ -50,6 +51,8 @@ var (
 
 // +kubebuilder:rbac:groups=dynamodb.services.k8s.aws,resources=tables,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=dynamodb.services.k8s.aws,resources=tables/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=kms.services.k8s.aws,resources=keys,verbs=get;list
+// +kubebuilder:rbac:groups=kms.services.k8s.aws,resources=keys/status,verbs=get;list
 
 var lateInitializeFieldNames = []string{}
 
@@ -271,7 +274,7 @@ func (rm *resourceManager) IsSynced(ctx context.Context, res acktypes.AWSResourc
 	if r.ko.Status.TableStatus == nil {
 		return false, nil
 	}
-	tableStatusCandidates := []string{"ACTIVE", "ARCHIVED"}
+	tableStatusCandidates := []string{"ACTIVE"}
 	if !ackutil.InStrings(*r.ko.Status.TableStatus, tableStatusCandidates) {
 		return false, nil
 	}
@@ -316,6 +319,8 @@ func newResourceManager(
 	id ackv1alpha1.AWSAccountID,
 	region ackv1alpha1.AWSRegion,
 ) (*resourceManager, error) {
+	sdkapi := svcsdk.New(sess)
+	throttle.Instrument(sdkapi.Client)
 	return &resourceManager{
 		cfg:          cfg,
 		log:          log,
@@ -324,7 +329,7 @@ func newResourceManager(
 		awsAccountID: id,
 		awsRegion:    region,
 		sess:         sess,
-		sdkapi:       svcsdk.New(sess),
+		sdkapi:       sdkapi,
 	}, nil
 }
 
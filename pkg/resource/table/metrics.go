@@ -0,0 +1,228 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlrtmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+// tableLabels are the labels identifying a managed table in every table
+// health metric.
+var tableLabels = []string{"namespace", "name", "table_name"}
+
+var (
+	tableStatusGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ack_dynamodb_table_status",
+			Help: "Current status of a managed table. The series with the current status is set to 1.",
+		},
+		append(tableLabels, "status"),
+	)
+	tableItemCountGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ack_dynamodb_table_item_count",
+			Help: "Number of items in a managed table, as reported by DynamoDB approximately every six hours.",
+		},
+		tableLabels,
+	)
+	tableSizeBytesGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ack_dynamodb_table_size_bytes",
+			Help: "Size of a managed table in bytes, as reported by DynamoDB approximately every six hours.",
+		},
+		tableLabels,
+	)
+	tableProvisionedReadCapacityGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ack_dynamodb_table_provisioned_read_capacity_units",
+			Help: "Provisioned read capacity units of a managed table. Not reported for PAY_PER_REQUEST tables.",
+		},
+		tableLabels,
+	)
+	tableProvisionedWriteCapacityGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ack_dynamodb_table_provisioned_write_capacity_units",
+			Help: "Provisioned write capacity units of a managed table. Not reported for PAY_PER_REQUEST tables.",
+		},
+		tableLabels,
+	)
+	tablePITREnabledGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ack_dynamodb_table_point_in_time_recovery_enabled",
+			Help: "Whether point in time recovery is enabled (1) or not (0) on a managed table.",
+		},
+		tableLabels,
+	)
+	tableTTLEnabledGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ack_dynamodb_table_time_to_live_enabled",
+			Help: "Whether Time to Live is enabled (1) or not (0) on a managed table.",
+		},
+		tableLabels,
+	)
+	tableGSIStatusGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ack_dynamodb_table_global_secondary_index_status",
+			Help: "Current status of a global secondary index of a managed table. The series with the current status is set to 1.",
+		},
+		append(tableLabels, "index_name", "status"),
+	)
+	tableGSIBackfillingGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ack_dynamodb_table_global_secondary_index_backfilling",
+			Help: "Whether a global secondary index of a managed table is being backfilled (1) or not (0).",
+		},
+		append(tableLabels, "index_name"),
+	)
+	tableReplicaStatusGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ack_dynamodb_table_replica_status",
+			Help: "Current status of a replica of a managed table. The series with the current status is set to 1.",
+		},
+		append(tableLabels, "region", "status"),
+	)
+)
+
+// tableHealthGauges are the gauges describing the health of managed tables.
+var tableHealthGauges = []*prometheus.GaugeVec{
+	tableStatusGauge,
+	tableItemCountGauge,
+	tableSizeBytesGauge,
+	tableProvisionedReadCapacityGauge,
+	tableProvisionedWriteCapacityGauge,
+	tablePITREnabledGauge,
+	tableTTLEnabledGauge,
+	tableGSIStatusGauge,
+	tableGSIBackfillingGauge,
+	tableReplicaStatusGauge,
+}
+
+func init() {
+	for _, gauge := range tableHealthGauges {
+		ctrlrtmetrics.Registry.MustRegister(gauge)
+	}
+}
+
+// tableMetricLabels returns the labels identifying the supplied table.
+func tableMetricLabels(ko *v1alpha1.Table) prometheus.Labels {
+	return prometheus.Labels{
+		"namespace":  ko.Namespace,
+		"name":       ko.Name,
+		"table_name": aws.StringValue(physicalTableName(ko)),
+	}
+}
+
+// withLabels returns a copy of the supplied labels with additional labels.
+func withLabels(labels prometheus.Labels, kv ...string) prometheus.Labels {
+	res := prometheus.Labels{}
+	for k, v := range labels {
+		res[k] = v
+	}
+	for i := 0; i+1 < len(kv); i += 2 {
+		res[kv[i]] = kv[i+1]
+	}
+	return res
+}
+
+// boolGaugeValue returns 1 if the supplied boolean is true, 0 otherwise.
+func boolGaugeValue(b *bool) float64 {
+	if aws.BoolValue(b) {
+		return 1
+	}
+	return 0
+}
+
+// observeTableMetrics updates the health metrics of a table from the fields
+// returned by DescribeTable.
+func observeTableMetrics(ko *v1alpha1.Table) {
+	labels := tableMetricLabels(ko)
+	// Statuses, indexes and replicas come and go, so we drop all the series
+	// of the table that have a variable label before setting the new ones.
+	for _, gauge := range []*prometheus.GaugeVec{
+		tableStatusGauge,
+		tableGSIStatusGauge,
+		tableGSIBackfillingGauge,
+		tableReplicaStatusGauge,
+		tableProvisionedReadCapacityGauge,
+		tableProvisionedWriteCapacityGauge,
+	} {
+		gauge.DeletePartialMatch(labels)
+	}
+
+	if ko.Status.TableStatus != nil {
+		tableStatusGauge.With(withLabels(labels, "status", *ko.Status.TableStatus)).Set(1)
+	}
+	if ko.Status.ItemCount != nil {
+		tableItemCountGauge.With(labels).Set(float64(*ko.Status.ItemCount))
+	}
+	if ko.Status.TableSizeBytes != nil {
+		tableSizeBytesGauge.With(labels).Set(float64(*ko.Status.TableSizeBytes))
+	}
+	if aws.StringValue(ko.Spec.BillingMode) != string(v1alpha1.BillingMode_PAY_PER_REQUEST) &&
+		ko.Spec.ProvisionedThroughput != nil {
+		tableProvisionedReadCapacityGauge.With(labels).Set(
+			float64(aws.Int64Value(ko.Spec.ProvisionedThroughput.ReadCapacityUnits)),
+		)
+		tableProvisionedWriteCapacityGauge.With(labels).Set(
+			float64(aws.Int64Value(ko.Spec.ProvisionedThroughput.WriteCapacityUnits)),
+		)
+	}
+	for _, gsi := range ko.Status.GlobalSecondaryIndexesDescriptions {
+		if gsi.IndexName == nil {
+			continue
+		}
+		if gsi.IndexStatus != nil {
+			tableGSIStatusGauge.With(
+				withLabels(labels, "index_name", *gsi.IndexName, "status", *gsi.IndexStatus),
+			).Set(1)
+		}
+		tableGSIBackfillingGauge.With(
+			withLabels(labels, "index_name", *gsi.IndexName),
+		).Set(boolGaugeValue(gsi.Backfilling))
+	}
+	for _, replica := range ko.Status.Replicas {
+		if replica.RegionName == nil || replica.ReplicaStatus == nil {
+			continue
+		}
+		tableReplicaStatusGauge.With(
+			withLabels(labels, "region", *replica.RegionName, "status", *replica.ReplicaStatus),
+		).Set(1)
+	}
+}
+
+// observeTableAdditionalFieldsMetrics updates the health metrics of a table
+// from the fields that are not returned by DescribeTable.
+func observeTableAdditionalFieldsMetrics(ko *v1alpha1.Table) {
+	labels := tableMetricLabels(ko)
+	if ko.Spec.ContinuousBackups != nil {
+		tablePITREnabledGauge.With(labels).Set(
+			boolGaugeValue(ko.Spec.ContinuousBackups.PointInTimeRecoveryEnabled),
+		)
+	}
+	if ko.Spec.TimeToLive != nil {
+		tableTTLEnabledGauge.With(labels).Set(boolGaugeValue(ko.Spec.TimeToLive.Enabled))
+	}
+}
+
+// forgetTableMetrics removes all the health metrics of a table.
+func forgetTableMetrics(ko *v1alpha1.Table) {
+	labels := tableMetricLabels(ko)
+	for _, gauge := range tableHealthGauges {
+		gauge.DeletePartialMatch(labels)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	svcsdk "github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+func newMetricsTable(namespace, name string) *v1alpha1.Table {
+	ko := &v1alpha1.Table{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: v1alpha1.TableSpec{
+			TableName:   aws.String(name + "-table"),
+			BillingMode: aws.String(string(v1alpha1.BillingMode_PROVISIONED)),
+			ProvisionedThroughput: &v1alpha1.ProvisionedThroughput{
+				ReadCapacityUnits:  aws.Int64(5),
+				WriteCapacityUnits: aws.Int64(10),
+			},
+			ContinuousBackups: &v1alpha1.PointInTimeRecoverySpecification{
+				PointInTimeRecoveryEnabled: aws.Bool(true),
+			},
+		},
+	}
+	ko.Status.TableStatus = aws.String(svcsdk.TableStatusActive)
+	ko.Status.ItemCount = aws.Int64(42)
+	ko.Status.TableSizeBytes = aws.Int64(1024)
+	ko.Status.GlobalSecondaryIndexesDescriptions = []*v1alpha1.GlobalSecondaryIndexDescription{{
+		IndexName:   aws.String("by-date"),
+		IndexStatus: aws.String(svcsdk.IndexStatusCreating),
+		Backfilling: aws.Bool(true),
+	}}
+	ko.Status.Replicas = []*v1alpha1.ReplicaDescription{{
+		RegionName:    aws.String("eu-west-1"),
+		ReplicaStatus: aws.String(svcsdk.ReplicaStatusActive),
+	}}
+	return ko
+}
+
+func Test_tableMetrics(t *testing.T) {
+	registry := prometheus.NewPedanticRegistry()
+	for _, gauge := range tableHealthGauges {
+		require.NoError(t, registry.Register(gauge))
+	}
+
+	orders := newMetricsTable("ns", "orders")
+	users := newMetricsTable("ns", "users")
+	for _, ko := range []*v1alpha1.Table{orders, users} {
+		observeTableMetrics(ko)
+		observeTableAdditionalFieldsMetrics(ko)
+		defer forgetTableMetrics(ko)
+	}
+
+	labels := tableMetricLabels(orders)
+	require.Equal(t, 1.0, testutil.ToFloat64(tableStatusGauge.With(withLabels(labels, "status", "ACTIVE"))))
+	require.Equal(t, 42.0, testutil.ToFloat64(tableItemCountGauge.With(labels)))
+	require.Equal(t, 1024.0, testutil.ToFloat64(tableSizeBytesGauge.With(labels)))
+	require.Equal(t, 5.0, testutil.ToFloat64(tableProvisionedReadCapacityGauge.With(labels)))
+	require.Equal(t, 10.0, testutil.ToFloat64(tableProvisionedWriteCapacityGauge.With(labels)))
+	require.Equal(t, 1.0, testutil.ToFloat64(tablePITREnabledGauge.With(labels)))
+	require.Equal(t, 1.0, testutil.ToFloat64(tableGSIBackfillingGauge.With(withLabels(labels, "index_name", "by-date"))))
+	require.Equal(t, 1.0, testutil.ToFloat64(tableReplicaStatusGauge.With(
+		withLabels(labels, "region", "eu-west-1", "status", "ACTIVE"),
+	)))
+
+	// A status change replaces the series of the previous status.
+	orders.Status.TableStatus = aws.String(svcsdk.TableStatusUpdating)
+	orders.Spec.BillingMode = aws.String(string(v1alpha1.BillingMode_PAY_PER_REQUEST))
+	observeTableMetrics(orders)
+	require.Equal(t, 2, testutil.CollectAndCount(tableStatusGauge))
+	require.Equal(t, 1.0, testutil.ToFloat64(tableStatusGauge.With(withLabels(labels, "status", "UPDATING"))))
+	require.Equal(t, 1, testutil.CollectAndCount(tableProvisionedReadCapacityGauge))
+
+	// Forgetting a table removes all its series and only them.
+	forgetTableMetrics(orders)
+	for _, gauge := range tableHealthGauges {
+		require.Zero(t, gauge.DeletePartialMatch(labels))
+	}
+	require.Equal(t, 1, testutil.CollectAndCount(tableStatusGauge))
+	require.Equal(t, 1, testutil.CollectAndCount(tableItemCountGauge))
+	require.Equal(t, 1, testutil.CollectAndCount(tablePITREnabledGauge))
+	require.Equal(t, 1, testutil.CollectAndCount(tableReplicaStatusGauge))
+	count, err := testutil.GatherAndCount(registry)
+	require.NoError(t, err)
+	require.Equal(t, 9, count)
+}
@@ -0,0 +1,130 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+// ConditionTypeDrifted is the type of the condition reporting whether the
+// desired state of an observe-only table differs from its observed state.
+const ConditionTypeDrifted ackv1alpha1.ConditionType = "Drifted"
+
+var errObserveOnlyCreate = ackerr.NewTerminalError(fmt.Errorf(
+	"table doesn't exist and cannot be created because the resource has the %s annotation",
+	v1alpha1.ObserveOnlyAnnotation,
+))
+
+// isObserveOnly returns true if the supplied table must only be observed by
+// the controller.
+func isObserveOnly(r *resource) bool {
+	return r.ko.GetAnnotations()[v1alpha1.ObserveOnlyAnnotation] == "true"
+}
+
+// reportDrift sets the Status.Drift field and the Drifted condition of the
+// latest state of an observe-only table. They are removed from the other
+// tables, including the ones that are no longer observe-only.
+func (rm *resourceManager) reportDrift(desired, latest *resource) {
+	if !isObserveOnly(desired) {
+		latest.ko.Status.Drift = nil
+		removeConditionOfType(latest, ConditionTypeDrifted)
+		return
+	}
+
+	// The controller adds its default tags to the desired tags, but it never
+	// tags observe-only tables. They are not reported as drift.
+	ko := desired.ko.DeepCopy()
+	ko.Spec.Tags = withoutDefaultTags(ko.Spec.Tags, rm.cfg.ResourceTags)
+	delta := withoutForcedDifferences(newResourceDelta(&resource{ko}, latest))
+
+	drift := make([]*v1alpha1.FieldDrift, 0, len(delta.Differences))
+	for _, diff := range delta.Differences {
+		drift = append(drift, &v1alpha1.FieldDrift{
+			Path:     aws.String(deltaPath(diff.Path)),
+			Desired:  aws.String(driftValue(diff.A)),
+			Observed: aws.String(driftValue(diff.B)),
+		})
+	}
+	sort.Slice(drift, func(i, j int) bool {
+		return *drift[i].Path < *drift[j].Path
+	})
+
+	if len(drift) == 0 {
+		latest.ko.Status.Drift = nil
+		msg := "observed state matches the desired state"
+		setConditionOfType(latest, ConditionTypeDrifted, corev1.ConditionFalse, &msg, nil)
+		return
+	}
+	paths := make([]string, 0, len(drift))
+	for _, d := range drift {
+		paths = append(paths, *d.Path)
+	}
+	latest.ko.Status.Drift = drift
+	msg := "observed state differs from the desired state at: " + strings.Join(paths, ", ")
+	setConditionOfType(latest, ConditionTypeDrifted, corev1.ConditionTrue, &msg, nil)
+}
+
+// withoutDefaultTags returns the supplied tags without the default tags
+// configured with the --resource-tags flag.
+func withoutDefaultTags(tags []*v1alpha1.Tag, resourceTags []string) []*v1alpha1.Tag {
+	defaultKeys := map[string]bool{}
+	for _, tagKeyVal := range resourceTags {
+		defaultKeys[strings.TrimSpace(strings.Split(tagKeyVal, "=")[0])] = true
+	}
+	var res []*v1alpha1.Tag
+	for _, tag := range tags {
+		if tag.Key != nil && defaultKeys[*tag.Key] {
+			continue
+		}
+		res = append(res, tag)
+	}
+	return res
+}
+
+// deltaPath returns the dotted notation of a delta path, e.g.
+// "Spec.ProvisionedThroughput".
+func deltaPath(p ackcompare.Path) string {
+	// Path doesn't expose its parts, other than through its JSON encoding.
+	b, err := json.Marshal(p)
+	if err != nil {
+		return ""
+	}
+	var parts struct {
+		Parts []string
+	}
+	if err := json.Unmarshal(b, &parts); err != nil {
+		return ""
+	}
+	return strings.Join(parts.Parts, ".")
+}
+
+// driftValue returns the JSON encoding of a delta value.
+func driftValue(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
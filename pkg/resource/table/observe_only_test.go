@@ -0,0 +1,66 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"testing"
+
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+func Test_reportDrift(t *testing.T) {
+	rm := &resourceManager{cfg: ackcfg.Config{
+		ResourceTags: []string{"services.k8s.aws/namespace=%K"},
+	}}
+	desired := &resource{ko: &v1alpha1.Table{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{v1alpha1.ObserveOnlyAnnotation: "true"},
+		},
+		Spec: v1alpha1.TableSpec{
+			TableClass: aws.String(string(v1alpha1.TableClass_STANDARD_INFREQUENT_ACCESS)),
+			Tags: []*v1alpha1.Tag{{
+				Key:   aws.String("services.k8s.aws/namespace"),
+				Value: aws.String("default"),
+			}},
+		},
+	}}
+
+	latest := &resource{ko: desired.ko.DeepCopy()}
+	latest.ko.Spec.Tags = nil
+	rm.reportDrift(desired, latest)
+	require.Nil(t, latest.ko.Status.Drift)
+	require.Equal(t, corev1.ConditionFalse, getConditionOfType(latest, ConditionTypeDrifted).Status)
+
+	latest = &resource{ko: desired.ko.DeepCopy()}
+	latest.ko.Spec.Tags = nil
+	latest.ko.Spec.TableClass = aws.String(string(v1alpha1.TableClass_STANDARD))
+	rm.reportDrift(desired, latest)
+	require.Equal(t, []*v1alpha1.FieldDrift{{
+		Path:     aws.String("Spec.TableClass"),
+		Desired:  aws.String(`"STANDARD_INFREQUENT_ACCESS"`),
+		Observed: aws.String(`"STANDARD"`),
+	}}, latest.ko.Status.Drift)
+	require.Equal(t, corev1.ConditionTrue, getConditionOfType(latest, ConditionTypeDrifted).Status)
+
+	delete(desired.ko.Annotations, v1alpha1.ObserveOnlyAnnotation)
+	rm.reportDrift(desired, latest)
+	require.Nil(t, latest.ko.Status.Drift)
+	require.Nil(t, getConditionOfType(latest, ConditionTypeDrifted))
+}
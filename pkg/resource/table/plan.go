@@ -0,0 +1,134 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	"github.com/aws/aws-sdk-go/aws"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/approval"
+)
+
+var (
+	ErrPlanNotApproved = fmt.Errorf("planned operations are waiting for approval")
+
+	requeueWaitPlanApproval = ackrequeue.NeededAfter(
+		ErrPlanNotApproved,
+		30*time.Second,
+	)
+)
+
+// newUpdatePlan returns the ordered list of operations of the supplied
+// steps of the update of a table.
+func newUpdatePlan(updates []*tableUpdate) []*v1alpha1.PlannedOperation {
+	var ops []*v1alpha1.PlannedOperation
+	for _, update := range updates {
+		ops = append(ops, update.ops...)
+	}
+	return ops
+}
+
+// describeChange returns a human readable description of the change of a
+// field value. The previous value is omitted when nil.
+func describeChange(field string, from, to interface{}) string {
+	if ackcompare.IsNil(from) {
+		return fmt.Sprintf("%s: %s", field, driftValue(to))
+	}
+	return fmt.Sprintf("%s: %s -> %s", field, driftValue(from), driftValue(to))
+}
+
+// holdUpdate records in ko the supplied operations planned to update a table
+// when the table has the plan-mode annotation or when destructive operations
+// are planned. It returns true if the operations must not be made yet, along
+// with the error to return to the reconciler.
+func holdUpdate(
+	ko *v1alpha1.Table,
+	desired *resource,
+	ops []*v1alpha1.PlannedOperation,
+) (bool, error) {
+	mode := desired.ko.GetAnnotations()[v1alpha1.PlanModeAnnotation]
+	approved := desired.ko.GetAnnotations()[v1alpha1.ApprovedPlanAnnotation]
+	if mode != v1alpha1.PlanModeDryRun {
+		// Operations are made over several reconciliations, the plan approved
+		// for the current generation of the resource stays approved until all
+		// its operations are made.
+		if plan := desired.ko.Status.Plan; plan != nil &&
+			aws.Int64Value(plan.Generation) == desired.ko.Generation &&
+			plan.Hash != nil && *plan.Hash == approved {
+			setPlanApproved(ko, approved)
+			return false, nil
+		}
+	}
+	if len(ops) == 0 {
+		ko.Status.Plan = nil
+		msg := "no operations planned"
+		setConditionOfType(&resource{ko}, approval.ConditionTypePendingApproval, corev1.ConditionFalse, &msg, nil)
+		return false, nil
+	}
+
+	var destructive []string
+	for _, op := range ops {
+		if approval.IsDestructive(*op.Action) {
+			destructive = append(destructive, *op.Description)
+		}
+	}
+	if mode != v1alpha1.PlanModeDryRun && mode != v1alpha1.PlanModeApprove && len(destructive) == 0 {
+		ko.Status.Plan = nil
+		removeConditionOfType(&resource{ko}, approval.ConditionTypePendingApproval)
+		return false, nil
+	}
+
+	hash := approval.PlanHash(ops, desired.ko.Generation)
+	ko.Status.Plan = &v1alpha1.UpdatePlan{
+		Hash:       aws.String(hash),
+		Generation: aws.Int64(desired.ko.Generation),
+		Operations: ops,
+	}
+
+	var msg string
+	switch {
+	case mode == v1alpha1.PlanModeDryRun:
+		msg = fmt.Sprintf("dry-run: %d operations planned, see Status.Plan", len(ops))
+		setSyncedCondition(&resource{ko}, corev1.ConditionFalse, &msg, nil)
+		return true, nil
+	case approved == hash:
+		setPlanApproved(ko, hash)
+		return false, nil
+	default:
+		msg = fmt.Sprintf(
+			"%d operations planned, waiting for the %s annotation to be set to %q",
+			len(ops), v1alpha1.ApprovedPlanAnnotation, hash,
+		)
+		if len(destructive) > 0 {
+			msg += ". Destructive operations: " + strings.Join(destructive, "; ")
+		}
+		setConditionOfType(&resource{ko}, approval.ConditionTypePendingApproval, corev1.ConditionTrue, &msg, nil)
+		setSyncedCondition(&resource{ko}, corev1.ConditionFalse, &msg, nil)
+		return true, requeueWaitPlanApproval
+	}
+}
+
+// setPlanApproved reports in ko that the plan with the supplied hash is
+// approved.
+func setPlanApproved(ko *v1alpha1.Table, hash string) {
+	msg := fmt.Sprintf("plan %s is approved", hash)
+	setConditionOfType(&resource{ko}, approval.ConditionTypePendingApproval, corev1.ConditionFalse, &msg, nil)
+}
@@ -0,0 +1,144 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/approval"
+)
+
+func newPlanTestResources() (desired, latest *resource) {
+	latest = &resource{ko: &v1alpha1.Table{
+		ObjectMeta: metav1.ObjectMeta{Generation: 2},
+		Spec: v1alpha1.TableSpec{
+			TableName:   aws.String("t"),
+			BillingMode: aws.String(string(v1alpha1.BillingMode_PROVISIONED)),
+			Tags:        []*v1alpha1.Tag{Tag1, Tag2},
+		},
+	}}
+	desired = &resource{ko: latest.ko.DeepCopy()}
+	desired.ko.Spec.BillingMode = aws.String(string(v1alpha1.BillingMode_PAY_PER_REQUEST))
+	desired.ko.Spec.Tags = []*v1alpha1.Tag{Tag1, Tag3}
+	return desired, latest
+}
+
+// newTestUpdatePlan returns the operations planned to update the latest
+// state of a table to its desired state.
+func newTestUpdatePlan(desired, latest *resource) []*v1alpha1.PlannedOperation {
+	rm := &resourceManager{}
+	return newUpdatePlan(rm.newTableUpdates(desired.ko.DeepCopy(), newResourceDelta(desired, latest), desired, latest))
+}
+
+func Test_newUpdatePlan(t *testing.T) {
+	desired, latest := newPlanTestResources()
+	ops := newTestUpdatePlan(desired, latest)
+
+	var actions []string
+	for _, op := range ops {
+		actions = append(actions, *op.Action)
+	}
+	require.Equal(t, []string{approval.ActionRemoveTags, approval.ActionAddTags, approval.ActionUpdateBillingMode}, actions)
+	require.Equal(t, "remove tags k2", *ops[0].Description)
+	require.Equal(t, `BillingMode: "PROVISIONED" -> "PAY_PER_REQUEST"`, *ops[2].Description)
+}
+
+func Test_holdUpdate(t *testing.T) {
+	desired, latest := newPlanTestResources()
+	ops := newTestUpdatePlan(desired, latest)
+
+	t.Run("no plan mode", func(t *testing.T) {
+		desired := &resource{ko: desired.ko.DeepCopy()}
+		desired.ko.Spec.BillingMode = latest.ko.Spec.BillingMode
+		ko := desired.ko.DeepCopy()
+		hold, err := holdUpdate(ko, desired, newTestUpdatePlan(desired, latest))
+		require.False(t, hold)
+		require.NoError(t, err)
+		require.Nil(t, ko.Status.Plan)
+		require.Nil(t, getConditionOfType(&resource{ko}, approval.ConditionTypePendingApproval))
+	})
+
+	t.Run("empty plan", func(t *testing.T) {
+		desired := &resource{ko: desired.ko.DeepCopy()}
+		desired.ko.Annotations = map[string]string{
+			v1alpha1.PlanModeAnnotation: v1alpha1.PlanModeApprove,
+		}
+		ko := desired.ko.DeepCopy()
+		setConditionOfType(&resource{ko}, approval.ConditionTypePendingApproval, corev1.ConditionTrue, nil, nil)
+		hold, err := holdUpdate(ko, desired, nil)
+		require.False(t, hold)
+		require.NoError(t, err)
+		require.Nil(t, ko.Status.Plan)
+		cond := getConditionOfType(&resource{ko}, approval.ConditionTypePendingApproval)
+		require.Equal(t, corev1.ConditionFalse, cond.Status)
+	})
+
+	t.Run("destructive operations", func(t *testing.T) {
+		ko := desired.ko.DeepCopy()
+		hold, err := holdUpdate(ko, desired, ops)
+		require.True(t, hold)
+		require.Equal(t, requeueWaitPlanApproval, err)
+		cond := getConditionOfType(&resource{ko}, approval.ConditionTypePendingApproval)
+		require.NotNil(t, cond)
+		require.Contains(t, *cond.Message, *ko.Status.Plan.Hash)
+	})
+
+	t.Run("dry-run", func(t *testing.T) {
+		desired.ko.Annotations = map[string]string{
+			v1alpha1.PlanModeAnnotation: v1alpha1.PlanModeDryRun,
+		}
+		ko := desired.ko.DeepCopy()
+		hold, err := holdUpdate(ko, desired, ops)
+		require.True(t, hold)
+		require.NoError(t, err)
+		require.Len(t, ko.Status.Plan.Operations, 3)
+	})
+
+	t.Run("approve", func(t *testing.T) {
+		desired.ko.Annotations = map[string]string{
+			v1alpha1.PlanModeAnnotation: v1alpha1.PlanModeApprove,
+		}
+		ko := desired.ko.DeepCopy()
+		hold, err := holdUpdate(ko, desired, ops)
+		require.True(t, hold)
+		require.Equal(t, requeueWaitPlanApproval, err)
+
+		desired.ko.Annotations[v1alpha1.ApprovedPlanAnnotation] = *ko.Status.Plan.Hash
+		ko = desired.ko.DeepCopy()
+		hold, err = holdUpdate(ko, desired, ops)
+		require.False(t, hold)
+		require.NoError(t, err)
+		cond := getConditionOfType(&resource{ko}, approval.ConditionTypePendingApproval)
+		require.Equal(t, corev1.ConditionFalse, cond.Status)
+
+		// The approved plan stays approved while its operations are made
+		desired.ko.Status.Plan = ko.Status.Plan
+		latest.ko.Spec.Tags = desired.ko.Spec.Tags
+		hold, _ = holdUpdate(desired.ko.DeepCopy(), desired, newTestUpdatePlan(desired, latest))
+		require.False(t, hold)
+
+		// The approval doesn't apply to a later generation.
+		desired.ko.Generation++
+		desired.ko.Status.Plan = nil
+		hold, err = holdUpdate(desired.ko.DeepCopy(), desired, ops)
+		require.True(t, hold)
+		require.Equal(t, requeueWaitPlanApproval, err)
+	})
+}
@@ -17,8 +17,14 @@ package table
 
 import (
 	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
 	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
 
 	svcapitypes "github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
@@ -31,6 +37,12 @@ import (
 func (rm *resourceManager) ClearResolvedReferences(res acktypes.AWSResource) acktypes.AWSResource {
 	ko := rm.concreteResource(res).ko.DeepCopy()
 
+	if ko.Spec.SSESpecification != nil {
+		if ko.Spec.SSESpecification.KMSMasterKeyRef != nil {
+			ko.Spec.SSESpecification.KMSMasterKeyID = nil
+		}
+	}
+
 	return &resource{ko}
 }
 
@@ -46,11 +58,115 @@ func (rm *resourceManager) ResolveReferences(
 	apiReader client.Reader,
 	res acktypes.AWSResource,
 ) (acktypes.AWSResource, bool, error) {
-	return res, false, nil
+	namespace := res.MetaObject().GetNamespace()
+	ko := rm.concreteResource(res).ko.DeepCopy()
+
+	resourceHasReferences := false
+	err := validateReferenceFields(ko)
+	if fieldHasReferences, err := rm.resolveReferenceForSSESpecification_KMSMasterKeyID(ctx, apiReader, namespace, ko); err != nil {
+		return &resource{ko}, (resourceHasReferences || fieldHasReferences), err
+	} else {
+		resourceHasReferences = resourceHasReferences || fieldHasReferences
+	}
+	if err := resolveMaintenanceWindow(ctx, apiReader, ko); err != nil {
+		return &resource{ko}, resourceHasReferences, err
+	}
+	if err := checkReferencedKeyEnabled(ctx, apiReader, ko); err != nil {
+		return &resource{ko}, resourceHasReferences, err
+	}
+
+	return &resource{ko}, resourceHasReferences, err
 }
 
 // validateReferenceFields validates the reference field and corresponding
 // identifier field.
 func validateReferenceFields(ko *svcapitypes.Table) error {
+	if ko.Spec.SSESpecification != nil {
+		if ko.Spec.SSESpecification.KMSMasterKeyRef != nil && ko.Spec.SSESpecification.KMSMasterKeyID != nil {
+			return ackerr.ResourceReferenceAndIDNotSupportedFor("SSESpecification.KMSMasterKeyID", "SSESpecification.KMSMasterKeyRef")
+		}
+	}
+	return nil
+}
+
+// resolveReferenceForSSESpecification_KMSMasterKeyID reads the resource
+// reference, reads the referenced resource and sets the resource reference
+// into the spec.
+func (rm *resourceManager) resolveReferenceForSSESpecification_KMSMasterKeyID(
+	ctx context.Context,
+	apiReader client.Reader,
+	namespace string,
+	ko *svcapitypes.Table,
+) (hasReferences bool, err error) {
+	if ko.Spec.SSESpecification != nil &&
+		ko.Spec.SSESpecification.KMSMasterKeyRef != nil &&
+		ko.Spec.SSESpecification.KMSMasterKeyRef.From != nil {
+		hasReferences = true
+		arr := ko.Spec.SSESpecification.KMSMasterKeyRef.From
+		if arr.Name == nil || *arr.Name == "" {
+			return hasReferences, fmt.Errorf("provided resource reference is nil or empty: SSESpecification.KMSMasterKeyRef")
+		}
+		obj := newReferencedKey()
+		if err := getReferencedResourceState_Key(ctx, apiReader, obj, *arr.Name, namespace); err != nil {
+			return hasReferences, err
+		}
+		arn, _, _ := unstructured.NestedString(obj.Object, "status", "ackResourceMetadata", "arn")
+		ko.Spec.SSESpecification.KMSMasterKeyID = &arn
+	}
+
+	return hasReferences, nil
+}
+
+// getReferencedResourceState_Key looks up whether a referenced resource
+// exists and is in a ACK.ResourceSynced=True state. If the referenced resource does exist and is
+// in a Synced state, returns nil, otherwise returns `ackerr.ResourceReferenceTerminalFor` or
+// `ResourceReferenceNotSyncedFor` depending on if the resource is in a Terminal state.
+func getReferencedResourceState_Key(
+	ctx context.Context,
+	apiReader client.Reader,
+	obj *unstructured.Unstructured,
+	name string, // the Kubernetes name of the referenced resource
+	namespace string, // the Kubernetes namespace of the referenced resource
+) error {
+	namespacedName := types.NamespacedName{
+		Namespace: namespace,
+		Name:      name,
+	}
+	err := apiReader.Get(ctx, namespacedName, obj)
+	if err != nil {
+		return err
+	}
+	var refResourceSynced, refResourceTerminal bool
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if cond["type"] == string(ackv1alpha1.ConditionTypeResourceSynced) &&
+			cond["status"] == "True" {
+			refResourceSynced = true
+		}
+		if cond["type"] == string(ackv1alpha1.ConditionTypeTerminal) &&
+			cond["status"] == "True" {
+			refResourceTerminal = true
+		}
+	}
+	if refResourceTerminal {
+		return ackerr.ResourceReferenceTerminalFor(
+			"Key",
+			namespace, name)
+	}
+	if !refResourceSynced {
+		return ackerr.ResourceReferenceNotSyncedFor(
+			"Key",
+			namespace, name)
+	}
+	if arn, _, _ := unstructured.NestedString(obj.Object, "status", "ackResourceMetadata", "arn"); arn == "" {
+		return ackerr.ResourceReferenceMissingTargetFieldFor(
+			"Key",
+			namespace, name,
+			"Status.ACKResourceMetadata.ARN")
+	}
 	return nil
 }
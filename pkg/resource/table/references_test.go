@@ -0,0 +1,93 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+const testKeyARN = "arn:aws:kms:us-west-2:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+
+func newTestKey(keyState string) *unstructured.Unstructured {
+	obj := newReferencedKey()
+	obj.SetNamespace("default")
+	obj.SetName("key")
+	_ = unstructured.SetNestedField(obj.Object, map[string]interface{}{
+		"keyState":            keyState,
+		"ackResourceMetadata": map[string]interface{}{"arn": testKeyARN},
+		"conditions": []interface{}{map[string]interface{}{
+			"type":   string(ackv1alpha1.ConditionTypeResourceSynced),
+			"status": "True",
+		}},
+	}, "status")
+	return obj
+}
+
+func newKeyReferencingTable() *resource {
+	return &resource{ko: &v1alpha1.Table{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "table"},
+		Spec: v1alpha1.TableSpec{
+			SSESpecification: &v1alpha1.SSESpecification{
+				Enabled: aws.Bool(true),
+				KMSMasterKeyRef: &ackv1alpha1.AWSResourceReferenceWrapper{
+					From: &ackv1alpha1.AWSResourceReference{Name: aws.String("key")},
+				},
+			},
+		},
+	}}
+}
+
+func TestResolveReferences_KMSMasterKey(t *testing.T) {
+	rm := &resourceManager{}
+	ctx := context.Background()
+
+	reader := fake.NewClientBuilder().WithObjects(newTestKey(keyStateEnabled)).Build()
+	resolved, hasReferences, err := rm.ResolveReferences(ctx, reader, newKeyReferencingTable())
+	require.NoError(t, err)
+	require.True(t, hasReferences)
+	require.Equal(t, testKeyARN, *resolved.(*resource).ko.Spec.SSESpecification.KMSMasterKeyID)
+
+	cleared := rm.ClearResolvedReferences(resolved)
+	require.Nil(t, cleared.(*resource).ko.Spec.SSESpecification.KMSMasterKeyID)
+
+	// Tables are only created once the key is enabled.
+	reader = fake.NewClientBuilder().WithObjects(newTestKey("Creating")).Build()
+	_, _, err = rm.ResolveReferences(ctx, reader, newKeyReferencingTable())
+	require.True(t, errors.Is(err, ackerr.ResourceReferenceNotSynced))
+
+	created := newKeyReferencingTable()
+	arn := ackv1alpha1.AWSResourceName("arn:aws:dynamodb:us-west-2:123456789012:table/t")
+	created.ko.Status.ACKResourceMetadata = &ackv1alpha1.ResourceMetadata{ARN: &arn}
+	_, _, err = rm.ResolveReferences(ctx, reader, created)
+	require.NoError(t, err)
+}
+
+func Test_validateReferenceFields(t *testing.T) {
+	r := newKeyReferencingTable()
+	require.NoError(t, validateReferenceFields(r.ko))
+	r.ko.Spec.SSESpecification.KMSMasterKeyID = aws.String(testKeyARN)
+	require.Error(t, validateReferenceFields(r.ko))
+}
@@ -0,0 +1,528 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go/aws"
+	svcsdk "github.com/aws/aws-sdk-go/service/dynamodb"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/dependents"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/publish"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/throttle"
+)
+
+// Phases of the table replacements reported in Status.Replacement.
+const (
+	ReplacementPhaseCreating         = "Creating"
+	ReplacementPhaseCopyingData      = "CopyingData"
+	ReplacementPhaseDeletingOldTable = "DeletingOldTable"
+	ReplacementPhaseCompleted        = "Completed"
+)
+
+// Values of the ReplacementStrategy fields.
+const (
+	DataCopyNone         = "None"
+	DataCopyScanAndWrite = "ScanAndWrite"
+
+	OldTablePolicyRetain = "Retain"
+	OldTablePolicyDelete = "Delete"
+)
+
+const (
+	// copyPageSize is the number of items copied to the new table per
+	// reconciliation.
+	copyPageSize = 100
+	// batchWriteSize is the maximum number of items of a BatchWriteItem call.
+	batchWriteSize = 25
+	// batchWriteAttempts is the number of times unprocessed items are
+	// written again before giving up.
+	batchWriteAttempts = 3
+	// maxTableNameLength is the maximum length of a DynamoDB table name.
+	maxTableNameLength = 255
+)
+
+var (
+	ErrTableReplacing                   = fmt.Errorf("table is being replaced")
+	ErrUnprocessedItems                 = fmt.Errorf("items could not be copied to the new table")
+	ErrDataCopyWriteLossNotAcknowledged = fmt.Errorf("data copy write loss not acknowledged")
+	replacementSuffixExp                = regexp.MustCompile(`-v[0-9]+$`)
+
+	requeueWaitWhileReplacing = ackrequeue.NeededAfter(
+		ErrTableReplacing,
+		10*time.Second,
+	)
+	requeueWaitWhileCopying = ackrequeue.NeededAfter(
+		ErrTableReplacing,
+		time.Second,
+	)
+	requeueWaitUnprocessedItems = ackrequeue.NeededAfter(
+		ErrUnprocessedItems,
+		10*time.Second,
+	)
+	requeueWaitDataCopyAcknowledged = ackrequeue.NeededAfter(
+		ErrDataCopyWriteLossNotAcknowledged,
+		30*time.Second,
+	)
+)
+
+// physicalTableName returns the name of the DynamoDB table of the supplied
+// table: Spec.TableName until the table is replaced, then the name of the new
+// table reported in Status.PhysicalTableName.
+func physicalTableName(ko *v1alpha1.Table) *string {
+	if ko.Status.PhysicalTableName != nil {
+		return ko.Status.PhysicalTableName
+	}
+	return ko.Spec.TableName
+}
+
+// isReplacing returns true if the new table of the supplied table is being
+// created or filled, before the table switches to it.
+func isReplacing(r *resource) bool {
+	replacement := r.ko.Status.Replacement
+	if replacement == nil || replacement.TableName == nil {
+		return false
+	}
+	switch aws.StringValue(replacement.Phase) {
+	case ReplacementPhaseDeletingOldTable, ReplacementPhaseCompleted:
+		return false
+	}
+	return true
+}
+
+// isDeletingReplacedTable returns true if the supplied table switched to its
+// new table and the old table is to be deleted.
+func isDeletingReplacedTable(r *resource) bool {
+	replacement := r.ko.Status.Replacement
+	return replacement != nil && replacement.PreviousTableName != nil &&
+		aws.StringValue(replacement.Phase) == ReplacementPhaseDeletingOldTable
+}
+
+// compareReplacement adds a difference at Spec.ReplacementStrategy to the
+// delta when the table replaced by the desired table is to be deleted. The
+// reconciler then updates the table even when it is synced, and
+// customUpdateTable deletes the old table.
+func compareReplacement(delta *ackcompare.Delta, desired *resource) {
+	if isDeletingReplacedTable(desired) {
+		delta.Add("Spec.ReplacementStrategy", desired.ko.Spec.ReplacementStrategy, nil)
+	}
+}
+
+// isDataCopyWriteLossAcknowledged returns true unless the supplied table
+// copies the items of its old table without the acknowledgement that the
+// items written during the copy can be lost.
+func isDataCopyWriteLossAcknowledged(r *resource) bool {
+	strategy := r.ko.Spec.ReplacementStrategy
+	if strategy == nil || aws.StringValue(strategy.DataCopy) != DataCopyScanAndWrite {
+		return true
+	}
+	return r.ko.GetAnnotations()[v1alpha1.DataCopyWriteLossAcknowledgedAnnotation] == "true"
+}
+
+// replacementTableName returns the name of the table replacing the table
+// with the supplied name, for the supplied generation of the resource.
+func replacementTableName(name string, generation int64) string {
+	base := replacementSuffixExp.ReplaceAllString(name, "")
+	suffix := fmt.Sprintf("-v%d", generation)
+	if len(base)+len(suffix) > maxTableNameLength {
+		base = base[:maxTableNameLength-len(suffix)]
+	}
+	return base + suffix
+}
+
+// nextReplacementTableName returns the name of the table replacing the
+// supplied table, the one of the replacement in progress if any.
+func nextReplacementTableName(r *resource) string {
+	if isReplacing(r) {
+		return *r.ko.Status.Replacement.TableName
+	}
+	return replacementTableName(aws.StringValue(r.ko.Spec.TableName), r.ko.Generation)
+}
+
+// describeReplacement returns a human readable description of the
+// replacement of the supplied table.
+func describeReplacement(desired *resource) string {
+	strategy := desired.ko.Spec.ReplacementStrategy
+	desc := fmt.Sprintf(
+		"replace table %s by table %s",
+		aws.StringValue(physicalTableName(desired.ko)), nextReplacementTableName(desired),
+	)
+	if aws.StringValue(strategy.DataCopy) == DataCopyScanAndWrite {
+		desc += ", copying its items"
+	}
+	if aws.StringValue(strategy.OldTablePolicy) == OldTablePolicyDelete {
+		desc += ", then delete it"
+	}
+	return desc
+}
+
+// setReplacementPhase records in ko the current phase of its replacement,
+// and reports it in the Synced condition.
+func setReplacementPhase(ko *v1alpha1.Table, phase string) {
+	now := metav1.Now()
+	ko.Status.Replacement.Phase = aws.String(phase)
+	ko.Status.Replacement.StartTime = &now
+	if phase == ReplacementPhaseCompleted {
+		return
+	}
+	msg := fmt.Sprintf(
+		"table is being replaced by table %s, current phase: %s",
+		*ko.Status.Replacement.TableName, phase,
+	)
+	setSyncedCondition(&resource{ko}, corev1.ConditionFalse, &msg, nil)
+}
+
+// replaceTable makes the next step of the replacement of a table whose key
+// schema or local secondary indexes changed: the new table is created with
+// the desired spec, the items of the old table are optionally copied, then
+// the resource is switched to the new table. ko is the resource returned to
+// the reconciler.
+func (rm *resourceManager) replaceTable(
+	ctx context.Context,
+	ko *v1alpha1.Table,
+	desired *resource,
+) (*resource, error) {
+	if !isReplacing(&resource{ko}) {
+		if !isDataCopyWriteLossAcknowledged(desired) {
+			msg := fmt.Sprintf(
+				"table is not replaced: the %s data copy loses the items written to the table during the copy, stop the writes and set the %s annotation to \"true\"",
+				DataCopyScanAndWrite, v1alpha1.DataCopyWriteLossAcknowledgedAnnotation,
+			)
+			setSyncedCondition(&resource{ko}, corev1.ConditionFalse, &msg, nil)
+			return &resource{ko}, requeueWaitDataCopyAcknowledged
+		}
+		name := nextReplacementTableName(desired)
+		if err := rm.createReplacementTable(ctx, desired, name); err != nil {
+			return nil, err
+		}
+		ko.Status.Replacement = &v1alpha1.TableReplacement{
+			Generation:        aws.Int64(desired.ko.Generation),
+			TableName:         aws.String(name),
+			PreviousTableName: aws.String(aws.StringValue(physicalTableName(desired.ko))),
+		}
+		setReplacementPhase(ko, ReplacementPhaseCreating)
+		return &resource{ko}, requeueWaitWhileReplacing
+	}
+
+	replacement := ko.Status.Replacement
+	switch aws.StringValue(replacement.Phase) {
+	case ReplacementPhaseCreating:
+		active, err := rm.isReplacementTableActive(ctx, *replacement.TableName)
+		if err != nil {
+			return nil, err
+		}
+		if !active {
+			return &resource{ko}, requeueWaitWhileReplacing
+		}
+		if aws.StringValue(desired.ko.Spec.ReplacementStrategy.DataCopy) == DataCopyScanAndWrite {
+			setReplacementPhase(ko, ReplacementPhaseCopyingData)
+			return &resource{ko}, requeueWaitWhileCopying
+		}
+	case ReplacementPhaseCopyingData:
+		done, err := rm.copyTablePage(ctx, aws.StringValue(physicalTableName(desired.ko)), replacement)
+		if err != nil {
+			return nil, err
+		}
+		if !done {
+			setReplacementPhase(ko, ReplacementPhaseCopyingData)
+			return &resource{ko}, requeueWaitWhileCopying
+		}
+	}
+	return rm.switchToReplacementTable(ctx, ko, desired)
+}
+
+// createReplacementTable creates the table with the supplied name replacing
+// the desired table.
+func (rm *resourceManager) createReplacementTable(
+	ctx context.Context,
+	desired *resource,
+	name string,
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.createReplacementTable")
+	defer func() { exit(err) }()
+
+	ko := desired.ko.DeepCopy()
+	ko.Spec.TableName = aws.String(name)
+	r := &resource{ko}
+	input, err := rm.newCreateRequestPayload(ctx, r)
+	if err != nil {
+		return err
+	}
+	if isAutoAttributeDefinitions(r) {
+		if input.AttributeDefinitions, err = newCreateAttributeDefinitions(r); err != nil {
+			return err
+		}
+	}
+	_, err = rm.sdkapi.CreateTableWithContext(ctx, input)
+	rm.metrics.RecordAPICall("CREATE", "CreateTable", err)
+	if awsErr, ok := ackerr.AWSError(err); ok && awsErr.Code() == "ResourceInUseException" {
+		// The table was created by a previous reconciliation.
+		return nil
+	}
+	return err
+}
+
+// isReplacementTableActive returns true if the table with the supplied name
+// is active.
+func (rm *resourceManager) isReplacementTableActive(ctx context.Context, name string) (bool, error) {
+	resp, err := rm.sdkapi.DescribeTableWithContext(ctx, &svcsdk.DescribeTableInput{
+		TableName: aws.String(name),
+	})
+	rm.metrics.RecordAPICall("READ_ONE", "DescribeTable", err)
+	if awsErr, ok := ackerr.AWSError(err); ok && awsErr.Code() == "ResourceNotFoundException" {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return aws.StringValue(resp.Table.TableStatus) == svcsdk.TableStatusActive, nil
+}
+
+// copyTablePage copies a page of the items of the table with the supplied
+// name to the new table of the replacement, starting after its copy cursor.
+// It returns true once all the items are copied.
+func (rm *resourceManager) copyTablePage(
+	ctx context.Context,
+	from string,
+	replacement *v1alpha1.TableReplacement,
+) (done bool, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.copyTablePage")
+	defer func() { exit(err) }()
+
+	input := &svcsdk.ScanInput{
+		TableName: aws.String(from),
+		Limit:     aws.Int64(copyPageSize),
+	}
+	if replacement.CopyCursor != nil {
+		if err := json.Unmarshal([]byte(*replacement.CopyCursor), &input.ExclusiveStartKey); err != nil {
+			return false, ackerr.NewTerminalError(fmt.Errorf("invalid copy cursor: %v", err))
+		}
+	}
+	resp, err := rm.sdkapi.ScanWithContext(ctx, input)
+	rm.metrics.RecordAPICall("GET", "Scan", err)
+	if err != nil {
+		return false, err
+	}
+	for start := 0; start < len(resp.Items); start += batchWriteSize {
+		end := start + batchWriteSize
+		if end > len(resp.Items) {
+			end = len(resp.Items)
+		}
+		if err := rm.putItems(ctx, *replacement.TableName, resp.Items[start:end]); err != nil {
+			return false, err
+		}
+	}
+
+	replacement.CopiedItems = aws.Int64(aws.Int64Value(replacement.CopiedItems) + int64(len(resp.Items)))
+	if len(resp.LastEvaluatedKey) == 0 {
+		replacement.CopyCursor = nil
+		return true, nil
+	}
+	cursor, err := json.Marshal(resp.LastEvaluatedKey)
+	if err != nil {
+		return false, err
+	}
+	replacement.CopyCursor = aws.String(string(cursor))
+	return false, nil
+}
+
+// putItems writes the supplied items to the table with the supplied name.
+func (rm *resourceManager) putItems(
+	ctx context.Context,
+	tableName string,
+	items []map[string]*svcsdk.AttributeValue,
+) error {
+	requests := make([]*svcsdk.WriteRequest, 0, len(items))
+	for _, item := range items {
+		requests = append(requests, &svcsdk.WriteRequest{
+			PutRequest: &svcsdk.PutRequest{Item: item},
+		})
+	}
+	for attempt := 0; attempt < batchWriteAttempts && len(requests) > 0; attempt++ {
+		resp, err := rm.sdkapi.BatchWriteItemWithContext(ctx, &svcsdk.BatchWriteItemInput{
+			RequestItems: map[string][]*svcsdk.WriteRequest{tableName: requests},
+		})
+		rm.metrics.RecordAPICall("CREATE", "BatchWriteItem", err)
+		if err != nil {
+			return err
+		}
+		requests = resp.UnprocessedItems[tableName]
+	}
+	if len(requests) > 0 {
+		// The page is copied again by the next reconciliation.
+		return requeueWaitUnprocessedItems
+	}
+	return nil
+}
+
+// switchToReplacementTable publishes the name of the new table of the
+// replacement and records it as the physical table of the resource. The old
+// table is deleted by a later reconciliation, once the switch is recorded, if
+// its policy says so.
+func (rm *resourceManager) switchToReplacementTable(
+	ctx context.Context,
+	ko *v1alpha1.Table,
+	desired *resource,
+) (*resource, error) {
+	replacement := ko.Status.Replacement
+	strategy := desired.ko.Spec.ReplacementStrategy
+
+	if strategy.ConfigMapName != nil {
+		if err := publish.TableName(ctx, ko.Namespace, *strategy.ConfigMapName, *replacement.TableName); err != nil {
+			return nil, err
+		}
+	}
+	additionalFields.invalidate(tableARN(desired))
+	forgetTableMetrics(desired.ko)
+
+	ko.Status.PhysicalTableName = aws.String(*replacement.TableName)
+	if aws.StringValue(strategy.OldTablePolicy) == OldTablePolicyDelete {
+		setReplacementPhase(ko, ReplacementPhaseDeletingOldTable)
+		return &resource{ko}, requeueWaitWhileReplacing
+	}
+	setReplacementPhase(ko, ReplacementPhaseCompleted)
+	return &resource{ko}, nil
+}
+
+// deleteReplacedTable makes the next step of the deletion of the table
+// replaced by the supplied table. Like the table itself, the old table is
+// deleted after its replicas and never while Backups or GlobalTables
+// reference it. It returns a copy of the supplied table and a requeue error
+// until the old table is deleted, then a copy whose replacement is completed.
+// Failures that retrying doesn't fix, such as the deletion protection of the
+// old table, are terminal.
+func (rm *resourceManager) deleteReplacedTable(
+	ctx context.Context,
+	desired *resource,
+) (updated *resource, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.deleteReplacedTable")
+	defer func() { exit(err) }()
+
+	ko := desired.ko.DeepCopy()
+	name := *ko.Status.Replacement.PreviousTableName
+	var old *resource
+	if aws.StringValue(desired.ko.Spec.ReplacementStrategy.OldTablePolicy) == OldTablePolicyDelete &&
+		name != aws.StringValue(physicalTableName(desired.ko)) {
+		if old, err = rm.readReplacedTable(ctx, desired, name); err != nil {
+			return nil, err
+		}
+	}
+	if old == nil {
+		// The old table is deleted, or retained since the switch.
+		setReplacementPhase(ko, ReplacementPhaseCompleted)
+		return &resource{ko}, nil
+	}
+	if isTableDeleting(old) {
+		return &resource{ko}, requeueWaitWhileReplacing
+	}
+
+	if ko.GetAnnotations()[v1alpha1.IgnoreDependentsAnnotation] != "true" {
+		deps, err := dependents.OfReplacedTable(ctx, ko, name)
+		if latest, err := holdForDependents(&resource{ko}, "replaced table "+name, deps, err); latest != nil || err != nil {
+			return latest, err
+		}
+	}
+	if latest, err := rm.deleteReplicas(ctx, old); latest != nil || err != nil {
+		ko.Status.Conditions = latest.ko.Status.Conditions
+		return &resource{ko}, err
+	}
+	if isTableUpdating(old) {
+		return &resource{ko}, requeueWaitWhileReplacing
+	}
+
+	_, err = rm.sdkapi.DeleteTableWithContext(ctx, &svcsdk.DeleteTableInput{
+		TableName: aws.String(name),
+	})
+	rm.metrics.RecordAPICall("DELETE", "DeleteTable", err)
+	if err == nil {
+		return &resource{ko}, requeueWaitWhileReplacing
+	}
+	awsErr, ok := ackerr.AWSError(err)
+	if !ok || throttle.IsThrottlingError(err) {
+		return nil, err
+	}
+	switch awsErr.Code() {
+	case "ResourceNotFoundException", "ResourceInUseException":
+		return &resource{ko}, requeueWaitWhileReplacing
+	case "InternalServerError":
+		return nil, err
+	}
+	return nil, ackerr.NewTerminalError(fmt.Errorf(
+		"cannot delete replaced table %s: %s: delete the table or set oldTablePolicy to %s",
+		name, awsErr.Message(), OldTablePolicyRetain,
+	))
+}
+
+// readReplacedTable returns a copy of the supplied table describing the table
+// with the supplied name it replaced, or nil once that table is deleted. The
+// copy only describes the status and replicas of the old table.
+func (rm *resourceManager) readReplacedTable(
+	ctx context.Context,
+	r *resource,
+	name string,
+) (*resource, error) {
+	resp, err := rm.sdkapi.DescribeTableWithContext(ctx, &svcsdk.DescribeTableInput{
+		TableName: aws.String(name),
+	})
+	rm.metrics.RecordAPICall("READ_ONE", "DescribeTable", err)
+	if awsErr, ok := ackerr.AWSError(err); ok && awsErr.Code() == "ResourceNotFoundException" {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	ko := r.ko.DeepCopy()
+	ko.Status.PhysicalTableName = aws.String(name)
+	ko.Status.TableStatus = resp.Table.TableStatus
+	ko.Status.Replicas = nil
+	for _, replica := range resp.Table.Replicas {
+		ko.Status.Replicas = append(ko.Status.Replicas, &v1alpha1.ReplicaDescription{
+			RegionName:                   replica.RegionName,
+			ReplicaStatus:                replica.ReplicaStatus,
+			ReplicaStatusPercentProgress: replica.ReplicaStatusPercentProgress,
+		})
+	}
+	return &resource{ko}, nil
+}
+
+// deleteReplacementTable deletes the new table of the replacement in
+// progress of the supplied table, if any.
+func (rm *resourceManager) deleteReplacementTable(ctx context.Context, r *resource) error {
+	if !isReplacing(r) {
+		return nil
+	}
+	_, err := rm.sdkapi.DeleteTableWithContext(ctx, &svcsdk.DeleteTableInput{
+		TableName: r.ko.Status.Replacement.TableName,
+	})
+	rm.metrics.RecordAPICall("DELETE", "DeleteTable", err)
+	if awsErr, ok := ackerr.AWSError(err); ok && awsErr.Code() == "ResourceNotFoundException" {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,279 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	svcsdk "github.com/aws/aws-sdk-go/service/dynamodb"
+	svcsdkapi "github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/approval"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/dependents"
+)
+
+func Test_replacementTableName(t *testing.T) {
+	require.Equal(t, "orders-v2", replacementTableName("orders", 2))
+	require.Equal(t, "orders-v7", replacementTableName("orders-v2", 7))
+	require.Equal(t, "orders-v2-x-v3", replacementTableName("orders-v2-x", 3))
+
+	name := replacementTableName(strings.Repeat("a", maxTableNameLength), 12)
+	require.Len(t, name, maxTableNameLength)
+	require.True(t, strings.HasSuffix(name, "-v12"))
+}
+
+func Test_physicalTableName(t *testing.T) {
+	ko := &v1alpha1.Table{Spec: v1alpha1.TableSpec{TableName: aws.String("t")}}
+	require.Equal(t, "t", *physicalTableName(ko))
+
+	ko.Status.PhysicalTableName = aws.String("t-v2")
+	require.Equal(t, "t-v2", *physicalTableName(ko))
+}
+
+func Test_isReplacing(t *testing.T) {
+	ko := &v1alpha1.Table{}
+	require.False(t, isReplacing(&resource{ko}))
+
+	ko.Status.Replacement = &v1alpha1.TableReplacement{TableName: aws.String("t-v2")}
+	setReplacementPhase(ko, ReplacementPhaseCopyingData)
+	require.True(t, isReplacing(&resource{ko}))
+	require.Equal(t, "t-v2", nextReplacementTableName(&resource{ko}))
+
+	ko.Status.Replacement.PreviousTableName = aws.String("t")
+	setReplacementPhase(ko, ReplacementPhaseDeletingOldTable)
+	require.False(t, isReplacing(&resource{ko}))
+	require.True(t, isDeletingReplacedTable(&resource{ko}))
+
+	setReplacementPhase(ko, ReplacementPhaseCompleted)
+	require.False(t, isReplacing(&resource{ko}))
+	require.False(t, isDeletingReplacedTable(&resource{ko}))
+}
+
+func Test_newUpdatePlan_replacement(t *testing.T) {
+	latest := &resource{ko: &v1alpha1.Table{
+		ObjectMeta: metav1.ObjectMeta{Generation: 3},
+		Spec:       newValidTableSpec(),
+	}}
+	desired := &resource{ko: latest.ko.DeepCopy()}
+	desired.ko.Spec.KeySchema = append(desired.ko.Spec.KeySchema, &v1alpha1.KeySchemaElement{
+		AttributeName: aws.String("sk"),
+		KeyType:       aws.String("RANGE"),
+	})
+	desired.ko.Spec.Tags = []*v1alpha1.Tag{{Key: aws.String("k"), Value: aws.String("v")}}
+	desired.ko.Spec.ReplacementStrategy = &v1alpha1.ReplacementStrategy{
+		DataCopy:       aws.String(DataCopyScanAndWrite),
+		OldTablePolicy: aws.String(OldTablePolicyDelete),
+	}
+
+	rm := &resourceManager{}
+	updates := rm.newTableUpdates(desired.ko.DeepCopy(), newResourceDelta(desired, latest), desired, latest)
+	ops := newUpdatePlan(updates)
+	require.Len(t, ops, 1)
+	require.Equal(t, approval.ActionReplaceTable, *ops[0].Action)
+	require.Equal(t, "replace table t by table t-v3, copying its items, then delete it", *ops[0].Description)
+}
+
+type fakeReplacedTableDynamoDB struct {
+	svcsdkapi.DynamoDBAPI
+	describeErr error
+	table       *svcsdk.TableDescription
+	deleteErr   error
+	deleted     []string
+	updated     []*svcsdk.UpdateTableInput
+}
+
+func (f *fakeReplacedTableDynamoDB) DescribeTableWithContext(
+	ctx aws.Context,
+	input *svcsdk.DescribeTableInput,
+	opts ...request.Option,
+) (*svcsdk.DescribeTableOutput, error) {
+	return &svcsdk.DescribeTableOutput{Table: f.table}, f.describeErr
+}
+
+func (f *fakeReplacedTableDynamoDB) DeleteTableWithContext(
+	ctx aws.Context,
+	input *svcsdk.DeleteTableInput,
+	opts ...request.Option,
+) (*svcsdk.DeleteTableOutput, error) {
+	f.deleted = append(f.deleted, *input.TableName)
+	return &svcsdk.DeleteTableOutput{}, f.deleteErr
+}
+
+func (f *fakeReplacedTableDynamoDB) UpdateTableWithContext(
+	ctx aws.Context,
+	input *svcsdk.UpdateTableInput,
+	opts ...request.Option,
+) (*svcsdk.UpdateTableOutput, error) {
+	f.updated = append(f.updated, input)
+	return &svcsdk.UpdateTableOutput{}, nil
+}
+
+func Test_switchToReplacementTable(t *testing.T) {
+	rm := &resourceManager{}
+	desired := &resource{ko: &v1alpha1.Table{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "orders"},
+		Spec: v1alpha1.TableSpec{
+			TableName:           aws.String("orders"),
+			ReplacementStrategy: &v1alpha1.ReplacementStrategy{OldTablePolicy: aws.String(OldTablePolicyDelete)},
+		},
+	}}
+	ko := desired.ko.DeepCopy()
+	ko.Status.Replacement = &v1alpha1.TableReplacement{
+		TableName:         aws.String("orders-v2"),
+		PreviousTableName: aws.String("orders"),
+	}
+
+	// The old table is only deleted once the switch is recorded.
+	latest, err := rm.switchToReplacementTable(context.TODO(), ko, desired)
+	require.Equal(t, requeueWaitWhileReplacing, err)
+	require.Equal(t, "orders", *latest.ko.Spec.TableName)
+	require.Equal(t, "orders-v2", *physicalTableName(latest.ko))
+	require.True(t, isDeletingReplacedTable(latest))
+	require.False(t, isReplacing(latest))
+
+	delta := ackcompare.NewDelta()
+	compareReplacement(delta, latest)
+	require.True(t, delta.DifferentAt("Spec"))
+	require.Empty(t, withoutForcedDifferences(delta).Differences)
+
+	desired.ko.Spec.ReplacementStrategy.OldTablePolicy = aws.String(OldTablePolicyRetain)
+	latest, err = rm.switchToReplacementTable(context.TODO(), ko, desired)
+	require.NoError(t, err)
+	require.Equal(t, ReplacementPhaseCompleted, *latest.ko.Status.Replacement.Phase)
+}
+
+func Test_replaceTable_dataCopyWriteLoss(t *testing.T) {
+	rm := &resourceManager{}
+	desired := &resource{ko: &v1alpha1.Table{
+		Spec: v1alpha1.TableSpec{
+			TableName:           aws.String("orders"),
+			ReplacementStrategy: &v1alpha1.ReplacementStrategy{DataCopy: aws.String(DataCopyScanAndWrite)},
+		},
+	}}
+
+	// The replacement doesn't start until the write loss is acknowledged.
+	latest, err := rm.replaceTable(context.TODO(), desired.ko.DeepCopy(), desired)
+	require.Equal(t, requeueWaitDataCopyAcknowledged, err)
+	require.Nil(t, latest.ko.Status.Replacement)
+	cond := getConditionOfType(latest, ackv1alpha1.ConditionTypeResourceSynced)
+	require.Equal(t, corev1.ConditionFalse, cond.Status)
+	require.Contains(t, *cond.Message, v1alpha1.DataCopyWriteLossAcknowledgedAnnotation)
+
+	desired.ko.Annotations = map[string]string{v1alpha1.DataCopyWriteLossAcknowledgedAnnotation: "true"}
+	require.True(t, isDataCopyWriteLossAcknowledged(desired))
+}
+
+func Test_deleteReplacedTable(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	reader := fake.NewClientBuilder().WithScheme(scheme).Build()
+	ctx := dependents.NewContext(context.Background(), reader)
+	sdkapi := &fakeReplacedTableDynamoDB{
+		table: &svcsdk.TableDescription{TableStatus: aws.String(svcsdk.TableStatusActive)},
+	}
+	rm := &resourceManager{sdkapi: sdkapi, metrics: ackmetrics.NewMetrics("dynamodb")}
+	desired := &resource{ko: &v1alpha1.Table{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "orders"},
+		Spec: v1alpha1.TableSpec{
+			TableName:           aws.String("orders"),
+			ReplacementStrategy: &v1alpha1.ReplacementStrategy{OldTablePolicy: aws.String(OldTablePolicyDelete)},
+		},
+		Status: v1alpha1.TableStatus{
+			PhysicalTableName: aws.String("orders-v2"),
+			Replacement: &v1alpha1.TableReplacement{
+				Phase:             aws.String(ReplacementPhaseDeletingOldTable),
+				TableName:         aws.String("orders-v2"),
+				PreviousTableName: aws.String("orders"),
+			},
+		},
+	}}
+
+	latest, err := rm.deleteReplacedTable(ctx, desired)
+	require.Equal(t, requeueWaitWhileReplacing, err)
+	require.Equal(t, []string{"orders"}, sdkapi.deleted)
+	require.True(t, isDeletingReplacedTable(latest))
+
+	// The old table is deleted once DynamoDB no longer finds it.
+	sdkapi.describeErr = awserr.New("ResourceNotFoundException", "not found", nil)
+	latest, err = rm.deleteReplacedTable(ctx, desired)
+	require.NoError(t, err)
+	require.Equal(t, ReplacementPhaseCompleted, *latest.ko.Status.Replacement.Phase)
+	require.Equal(t, "orders-v2", *physicalTableName(latest.ko))
+	sdkapi.describeErr = nil
+
+	sdkapi.deleted = nil
+	sdkapi.deleteErr = awserr.New("ValidationException", "deletion protection is enabled", nil)
+	_, err = rm.deleteReplacedTable(ctx, desired)
+	var terminal *ackerr.TerminalError
+	require.ErrorAs(t, err, &terminal)
+	require.Contains(t, err.Error(), "deletion protection is enabled")
+	require.Contains(t, err.Error(), OldTablePolicyRetain)
+	sdkapi.deleteErr = nil
+
+	// Its replicas are deleted first, per the replica deletion policy.
+	sdkapi.deleted = nil
+	sdkapi.table.Replicas = []*svcsdk.ReplicaDescription{{
+		RegionName:    aws.String("eu-west-1"),
+		ReplicaStatus: aws.String(svcsdk.ReplicaStatusActive),
+	}}
+	desired.ko.Annotations = map[string]string{
+		v1alpha1.ReplicaDeletionPolicyAnnotation: v1alpha1.ReplicaDeletionPolicyRefuse,
+	}
+	latest, err = rm.deleteReplacedTable(ctx, desired)
+	require.Equal(t, requeueWaitReplicaDeletionPolicy, err)
+	require.NotNil(t, getConditionOfType(latest, ConditionTypeReplicasDeleted))
+	require.Empty(t, sdkapi.deleted)
+
+	desired.ko.Annotations[v1alpha1.ReplicaDeletionPolicyAnnotation] = v1alpha1.ReplicaDeletionPolicyDelete
+	_, err = rm.deleteReplacedTable(ctx, desired)
+	require.Equal(t, requeueWaitReplicasDeleted, err)
+	require.Len(t, sdkapi.updated, 1)
+	require.Equal(t, "orders", *sdkapi.updated[0].TableName)
+	require.Empty(t, sdkapi.deleted)
+	sdkapi.table.Replicas = nil
+
+	// Nor while resources reference it.
+	ctx = dependents.NewContext(context.Background(), fake.NewClientBuilder().WithScheme(scheme).WithObjects(&v1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "nightly"},
+		Spec:       v1alpha1.BackupSpec{TableName: aws.String("orders")},
+	}).Build())
+	latest, err = rm.deleteReplacedTable(ctx, desired)
+	require.Equal(t, requeueWaitDependentsDeleted, err)
+	cond := getConditionOfType(latest, ConditionTypeDependentsExist)
+	require.Contains(t, *cond.Message, "replaced table orders")
+	require.Contains(t, *cond.Message, "Backup/nightly")
+	require.Empty(t, sdkapi.deleted)
+
+	// Retained tables are left alone.
+	desired.ko.Spec.ReplacementStrategy.OldTablePolicy = aws.String(OldTablePolicyRetain)
+	latest, err = rm.deleteReplacedTable(ctx, desired)
+	require.NoError(t, err)
+	require.Equal(t, ReplacementPhaseCompleted, *latest.ko.Status.Replacement.Phase)
+	require.Empty(t, sdkapi.deleted)
+}
@@ -0,0 +1,152 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go/aws"
+	svcsdk "github.com/aws/aws-sdk-go/service/dynamodb"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/approval"
+)
+
+// ConditionTypeReplicasDeleted is the type of the condition reporting the
+// deletion of the replicas of a table being deleted.
+const ConditionTypeReplicasDeleted ackv1alpha1.ConditionType = "ReplicasDeleted"
+
+var (
+	ErrTableHasReplicas              = fmt.Errorf("table has replicas and cannot be deleted")
+	ErrReplicasDeleting              = fmt.Errorf("waiting for the replicas of the table to be deleted")
+	requeueWaitReplicaDeletionPolicy = ackrequeue.NeededAfter(
+		ErrTableHasReplicas,
+		30*time.Second,
+	)
+	requeueWaitReplicasDeleted = ackrequeue.NeededAfter(
+		ErrReplicasDeleting,
+		15*time.Second,
+	)
+)
+
+// isReplicaDeletionRefused returns true if the supplied table must not be
+// deleted while it has replicas.
+func isReplicaDeletionRefused(r *resource) bool {
+	switch r.ko.GetAnnotations()[v1alpha1.ReplicaDeletionPolicyAnnotation] {
+	case v1alpha1.ReplicaDeletionPolicyDelete:
+		return false
+	case v1alpha1.ReplicaDeletionPolicyRefuse:
+		return true
+	}
+	return approval.IsDestructive(approval.ActionDeleteReplica)
+}
+
+// deleteReplicas deletes, one per reconciliation, the replicas of a table
+// being deleted, whose latest state is supplied. DynamoDB doesn't delete
+// tables with replicas. It returns the table with the ReplicasDeleted
+// condition and a requeue error until every replica is deleted, nil once the
+// table can be deleted.
+func (rm *resourceManager) deleteReplicas(
+	ctx context.Context,
+	r *resource,
+) (latest *resource, err error) {
+	if len(r.ko.Status.Replicas) == 0 {
+		return nil, nil
+	}
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.deleteReplicas")
+	defer func() { exit(err) }()
+
+	ko := r.ko.DeepCopy()
+	if isReplicaDeletionRefused(r) {
+		msg := fmt.Sprintf(
+			"table has replicas in %s and is not deleted: delete the replicas or set the %s annotation to %q",
+			strings.Join(replicaRegionNames(r), ", "),
+			v1alpha1.ReplicaDeletionPolicyAnnotation, v1alpha1.ReplicaDeletionPolicyDelete,
+		)
+		setConditionOfType(&resource{ko}, ConditionTypeReplicasDeleted, corev1.ConditionFalse, &msg, nil)
+		return &resource{ko}, requeueWaitReplicaDeletionPolicy
+	}
+
+	msg := "deleting replicas: " + describeReplicaStatuses(r)
+	setConditionOfType(&resource{ko}, ConditionTypeReplicasDeleted, corev1.ConditionFalse, &msg, nil)
+	region := nextReplicaToDelete(r)
+	if region == nil || isTableUpdating(r) {
+		// DynamoDB updates a single replica of a table at a time.
+		return &resource{ko}, requeueWaitReplicasDeleted
+	}
+	_, err = rm.sdkapi.UpdateTableWithContext(ctx, &svcsdk.UpdateTableInput{
+		TableName: physicalTableName(r.ko),
+		ReplicaUpdates: []*svcsdk.ReplicationGroupUpdate{{
+			Delete: &svcsdk.DeleteReplicationGroupMemberAction{RegionName: region},
+		}},
+	})
+	rm.metrics.RecordAPICall("UPDATE", "UpdateTable", err)
+	if awsErr, ok := ackerr.AWSError(err); ok && awsErr.Code() == "ResourceInUseException" {
+		err = nil
+	}
+	if err != nil {
+		return &resource{ko}, requeueOnThrottle(r, err)
+	}
+	return &resource{ko}, requeueWaitReplicasDeleted
+}
+
+// nextReplicaToDelete returns the Region of the first replica of the supplied
+// table that isn't being deleted, or nil.
+func nextReplicaToDelete(r *resource) *string {
+	for _, replica := range r.ko.Status.Replicas {
+		if replica != nil && replica.RegionName != nil &&
+			aws.StringValue(replica.ReplicaStatus) != svcsdk.ReplicaStatusDeleting {
+			return replica.RegionName
+		}
+	}
+	return nil
+}
+
+// replicaRegionNames returns the Regions of the replicas of the supplied
+// table.
+func replicaRegionNames(r *resource) []string {
+	var regions []string
+	for _, replica := range r.ko.Status.Replicas {
+		if replica != nil {
+			regions = append(regions, aws.StringValue(replica.RegionName))
+		}
+	}
+	return regions
+}
+
+// describeReplicaStatuses returns the Regions and statuses of the replicas of
+// the supplied table.
+func describeReplicaStatuses(r *resource) string {
+	var statuses []string
+	for _, replica := range r.ko.Status.Replicas {
+		if replica == nil {
+			continue
+		}
+		status := fmt.Sprintf("%s is %s", aws.StringValue(replica.RegionName), aws.StringValue(replica.ReplicaStatus))
+		if progress := aws.StringValue(replica.ReplicaStatusPercentProgress); progress != "" {
+			status += fmt.Sprintf(" (%s%%)", progress)
+		}
+		statuses = append(statuses, status)
+	}
+	return strings.Join(statuses, ", ")
+}
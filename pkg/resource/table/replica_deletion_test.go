@@ -0,0 +1,104 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"context"
+	"testing"
+
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	svcsdk "github.com/aws/aws-sdk-go/service/dynamodb"
+	svcsdkapi "github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+type fakeReplicaDynamoDB struct {
+	svcsdkapi.DynamoDBAPI
+	updates []*svcsdk.UpdateTableInput
+}
+
+func (f *fakeReplicaDynamoDB) UpdateTableWithContext(
+	ctx aws.Context,
+	input *svcsdk.UpdateTableInput,
+	opts ...request.Option,
+) (*svcsdk.UpdateTableOutput, error) {
+	f.updates = append(f.updates, input)
+	return &svcsdk.UpdateTableOutput{}, nil
+}
+
+func newReplicatedTable(policy string, statuses ...string) *resource {
+	ko := &v1alpha1.Table{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{v1alpha1.ReplicaDeletionPolicyAnnotation: policy},
+		},
+		Spec: v1alpha1.TableSpec{TableName: aws.String("t")},
+	}
+	ko.Status.TableStatus = aws.String(svcsdk.TableStatusActive)
+	regions := []string{"us-west-2", "eu-west-1"}
+	for i, status := range statuses {
+		ko.Status.Replicas = append(ko.Status.Replicas, &v1alpha1.ReplicaDescription{
+			RegionName:    aws.String(regions[i]),
+			ReplicaStatus: aws.String(status),
+		})
+	}
+	return &resource{ko}
+}
+
+func Test_deleteReplicas(t *testing.T) {
+	sdkapi := &fakeReplicaDynamoDB{}
+	rm := &resourceManager{sdkapi: sdkapi, metrics: ackmetrics.NewMetrics("dynamodb")}
+	ctx := context.TODO()
+
+	t.Run("no replicas", func(t *testing.T) {
+		latest, err := rm.deleteReplicas(ctx, newReplicatedTable(v1alpha1.ReplicaDeletionPolicyRefuse))
+		require.NoError(t, err)
+		require.Nil(t, latest)
+	})
+
+	t.Run("refused", func(t *testing.T) {
+		r := newReplicatedTable(v1alpha1.ReplicaDeletionPolicyRefuse, svcsdk.ReplicaStatusActive)
+		latest, err := rm.deleteReplicas(ctx, r)
+		require.Equal(t, requeueWaitReplicaDeletionPolicy, err)
+		cond := getConditionOfType(latest, ConditionTypeReplicasDeleted)
+		require.Equal(t, corev1.ConditionFalse, cond.Status)
+		require.Contains(t, *cond.Message, "us-west-2")
+		require.Contains(t, *cond.Message, v1alpha1.ReplicaDeletionPolicyAnnotation)
+		require.Empty(t, sdkapi.updates)
+	})
+
+	t.Run("deleted one at a time", func(t *testing.T) {
+		r := newReplicatedTable(
+			v1alpha1.ReplicaDeletionPolicyDelete,
+			svcsdk.ReplicaStatusDeleting, svcsdk.ReplicaStatusActive,
+		)
+		latest, err := rm.deleteReplicas(ctx, r)
+		require.Equal(t, requeueWaitReplicasDeleted, err)
+		require.Len(t, sdkapi.updates, 1)
+		require.Equal(t, "eu-west-1", *sdkapi.updates[0].ReplicaUpdates[0].Delete.RegionName)
+		cond := getConditionOfType(latest, ConditionTypeReplicasDeleted)
+		require.Equal(t, "deleting replicas: us-west-2 is DELETING, eu-west-1 is ACTIVE", *cond.Message)
+
+		// Nothing is sent while the table is updated.
+		r.ko.Status.TableStatus = aws.String(svcsdk.TableStatusUpdating)
+		_, err = rm.deleteReplicas(ctx, r)
+		require.Equal(t, requeueWaitReplicasDeleted, err)
+		require.Len(t, sdkapi.updates, 1)
+	})
+}
@@ -72,9 +72,11 @@ func (rm *resourceManager) sdkFind(
 	if err != nil {
 		return nil, err
 	}
+	input.TableName = physicalTableName(r.ko)
 
 	var resp *svcsdk.DescribeTableOutput
 	resp, err = rm.sdkapi.DescribeTableWithContext(ctx, input)
+	err = requeueOnThrottle(r, err)
 	rm.metrics.RecordAPICall("READ_ONE", "DescribeTable", err)
 	if err != nil {
 		if reqErr, ok := ackerr.AWSRequestFailure(err); ok && reqErr.StatusCode() == 404 {
@@ -392,6 +394,10 @@ func (rm *resourceManager) sdkFind(
 	}
 
 	rm.setStatusDefaults(ko)
+	// The table read is the physical table of the resource, whose name only
+	// differs from Spec.TableName once the table is replaced.
+	ko.Status.PhysicalTableName = ko.Spec.TableName
+	ko.Spec.TableName = r.ko.Spec.TableName
 	if resp.Table.GlobalSecondaryIndexes != nil {
 		f := []*svcapitypes.GlobalSecondaryIndexDescription{}
 		for _, fIter := range resp.Table.GlobalSecondaryIndexes {
@@ -414,6 +420,7 @@ func (rm *resourceManager) sdkFind(
 			if fIter.Backfilling != nil {
 				fElem.Backfilling = fIter.Backfilling
 			}
+			fElem.ProvisionedThroughput = newProvisionedThroughputDescription(fIter.ProvisionedThroughput)
 			f = append(f, fElem)
 		}
 		ko.Status.GlobalSecondaryIndexesDescriptions = f
@@ -447,6 +454,29 @@ func (rm *resourceManager) sdkFind(
 	} else {
 		ko.Spec.BillingMode = aws.String("PROVISIONED")
 	}
+	ko.Status.BillingModeSummary = newBillingModeSummary(resp.Table.BillingModeSummary)
+	ko.Status.ProvisionedThroughputDescription = newProvisionedThroughputDescription(resp.Table.ProvisionedThroughput)
+	if !isTableDeleting(&resource{ko}) {
+		observeTableMetrics(ko)
+	}
+	// The update plan and the next maintenance window are only reported
+	// while there are changes to make, they are set by customUpdateTable.
+	ko.Status.Plan = nil
+	ko.Status.NextMaintenanceWindow = nil
+	// DynamoDB can access the key again.
+	if !isEncryptionKeyInaccessible(&resource{ko}) {
+		removeConditionOfType(&resource{ko}, ConditionTypeEncryptionKeyInaccessible)
+	}
+	// Tables whose key is inaccessible or that were archived can still be
+	// deleted.
+	if !r.IsBeingDeleted() {
+		if isEncryptionKeyInaccessible(&resource{ko}) {
+			return &resource{ko}, rm.reportInaccessibleEncryptionKey(ctx, rm.newKMSAPI(), ko, resp.Table.SSEDescription)
+		}
+		if isTableArchived(&resource{ko}) {
+			return &resource{ko}, newTableArchivedError(ko)
+		}
+	}
 	if isTableCreating(&resource{ko}) {
 		return &resource{ko}, requeueWaitWhileCreating
 	}
@@ -456,6 +486,10 @@ func (rm *resourceManager) sdkFind(
 	if err := rm.setResourceAdditionalFields(ctx, ko); err != nil {
 		return nil, err
 	}
+	reportTagOwnership(r, &resource{ko})
+	rm.reportDrift(r, &resource{ko})
+	pruneTransitions(r, &resource{ko})
+	reportCapacitySchedule(r, &resource{ko})
 	return &resource{ko}, nil
 }
 
@@ -495,14 +529,23 @@ func (rm *resourceManager) sdkCreate(
 	defer func() {
 		exit(err)
 	}()
+	if isObserveOnly(desired) {
+		return nil, errObserveOnlyCreate
+	}
 	input, err := rm.newCreateRequestPayload(ctx, desired)
 	if err != nil {
 		return nil, err
 	}
+	if isAutoAttributeDefinitions(desired) {
+		if input.AttributeDefinitions, err = newCreateAttributeDefinitions(desired); err != nil {
+			return nil, err
+		}
+	}
 
 	var resp *svcsdk.CreateTableOutput
 	_ = resp
 	resp, err = rm.sdkapi.CreateTableWithContext(ctx, input)
+	err = requeueOnThrottle(desired, err)
 	rm.metrics.RecordAPICall("CREATE", "CreateTable", err)
 	if err != nil {
 		return nil, err
@@ -813,7 +856,14 @@ func (rm *resourceManager) sdkCreate(
 	}
 
 	rm.setStatusDefaults(ko)
-	if desired.ko.Spec.TimeToLive != nil {
+	// Forget about any fields cached for a previous table with the same ARN.
+	additionalFields.invalidate(tableARN(&resource{ko}))
+	ko.Status.PhysicalTableName = ko.Spec.TableName
+	if isAutoAttributeDefinitions(desired) {
+		// Keep the declared attribute types, including the unused ones.
+		ko.Spec.AttributeDefinitions = desired.ko.Spec.AttributeDefinitions
+	}
+	if isTTLEnabled(desired.ko.Spec.TimeToLive) {
 		if err := rm.syncTTL(ctx, desired, &resource{ko}); err != nil {
 			return nil, err
 		}
@@ -1030,19 +1080,38 @@ func (rm *resourceManager) sdkDelete(
 	defer func() {
 		exit(err)
 	}()
+	if isObserveOnly(r) {
+		// Observe-only tables are left untouched in DynamoDB
+		additionalFields.invalidate(tableARN(r))
+		forgetTableMetrics(r.ko)
+		return nil, nil
+	}
 	if isTableDeleting(r) {
 		return nil, requeueWaitWhileDeleting
 	}
+	if latest, err := holdDeletionForDependents(ctx, r); latest != nil || err != nil {
+		return latest, err
+	}
+	if latest, err := rm.deleteReplicas(ctx, r); latest != nil || err != nil {
+		return latest, err
+	}
 	if isTableUpdating(r) {
 		return nil, requeueWaitWhileUpdating
 	}
+	if err := rm.deleteReplacementTable(ctx, r); err != nil {
+		return nil, err
+	}
+	additionalFields.invalidate(tableARN(r))
+	forgetTableMetrics(r.ko)
 	input, err := rm.newDeleteRequestPayload(r)
 	if err != nil {
 		return nil, err
 	}
+	input.TableName = physicalTableName(r.ko)
 	var resp *svcsdk.DeleteTableOutput
 	_ = resp
 	resp, err = rm.sdkapi.DeleteTableWithContext(ctx, input)
+	err = requeueOnThrottle(r, err)
 	rm.metrics.RecordAPICall("DELETE", "DeleteTable", err)
 	return nil, err
 }
@@ -0,0 +1,96 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/tags"
+)
+
+// isManagedTagOwnership returns true if the controller only manages the tags
+// it applied to the supplied table.
+func isManagedTagOwnership(r *resource) bool {
+	return r.ko.GetAnnotations()[v1alpha1.TagOwnershipAnnotation] == v1alpha1.TagOwnershipManaged
+}
+
+// reportTagOwnership removes from the tags read from DynamoDB the ones the
+// controller leaves alone, so they are never compared with the desired tags:
+// the tags assigned by AWS and, for tables with managed tag ownership, the
+// tags neither desired nor previously applied by the controller. The latter
+// are reported in Status.ForeignTags, and the keys of the others in
+// Status.ManagedTagKeys.
+func reportTagOwnership(desired, latest *resource) {
+	managed := isManagedTagOwnership(desired)
+	owned := map[string]bool{}
+	if managed {
+		for _, tag := range desired.ko.Spec.Tags {
+			if tag != nil && tag.Key != nil {
+				owned[*tag.Key] = true
+			}
+		}
+		for _, key := range desired.ko.Status.ManagedTagKeys {
+			if key != nil {
+				owned[*key] = true
+			}
+		}
+	}
+
+	kept := []*v1alpha1.Tag{}
+	var foreign []*v1alpha1.Tag
+	var keys []string
+	for _, tag := range latest.ko.Spec.Tags {
+		key := aws.StringValue(tag.Key)
+		switch {
+		case tags.IsAWSTag(key):
+		case !managed:
+			kept = append(kept, tag)
+		case owned[key]:
+			kept = append(kept, tag)
+			keys = append(keys, key)
+		default:
+			foreign = append(foreign, tag)
+		}
+	}
+	latest.ko.Spec.Tags = kept
+	latest.ko.Status.ForeignTags = foreign
+	latest.ko.Status.ManagedTagKeys = sortedTagKeys(keys)
+}
+
+// setManagedTagKeys records in ko the keys of the tags applied to a table
+// with managed tag ownership, once its tags are synced with the desired ones.
+func setManagedTagKeys(ko *v1alpha1.Table, desired *resource) {
+	if !isManagedTagOwnership(desired) {
+		return
+	}
+	var keys []string
+	for _, tag := range desired.ko.Spec.Tags {
+		if tag != nil && tag.Key != nil {
+			keys = append(keys, *tag.Key)
+		}
+	}
+	ko.Status.ManagedTagKeys = sortedTagKeys(keys)
+}
+
+// sortedTagKeys returns the supplied keys sorted, or nil if there are none.
+func sortedTagKeys(keys []string) []*string {
+	if len(keys) == 0 {
+		return nil
+	}
+	sort.Strings(keys)
+	return aws.StringSlice(keys)
+}
@@ -0,0 +1,63 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/tags"
+)
+
+func Test_reportTagOwnership(t *testing.T) {
+	desired := &resource{ko: &v1alpha1.Table{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+			v1alpha1.TagOwnershipAnnotation: v1alpha1.TagOwnershipManaged,
+		}},
+		Spec: v1alpha1.TableSpec{Tags: []*v1alpha1.Tag{Tag2Updated}},
+	}}
+	desired.ko.Status.ManagedTagKeys = aws.StringSlice([]string{"k1"})
+	observed := []*v1alpha1.Tag{
+		Tag2,
+		Tag1,
+		Tag3,
+		{Key: aws.String("aws:cloudformation:stack-name"), Value: aws.String("stack")},
+	}
+
+	latest := &resource{ko: desired.ko.DeepCopy()}
+	latest.ko.Spec.Tags = observed
+	reportTagOwnership(desired, latest)
+	require.Equal(t, []*v1alpha1.Tag{Tag2, Tag1}, latest.ko.Spec.Tags)
+	require.Equal(t, []*v1alpha1.Tag{Tag3}, latest.ko.Status.ForeignTags)
+	require.Equal(t, []string{"k1", "k2"}, aws.StringValueSlice(latest.ko.Status.ManagedTagKeys))
+
+	// The k1 tag applied by the controller is removed, the foreign tag is
+	// left alone.
+	added, removed := tags.ComputeDelta(desired.ko.Spec.Tags, latest.ko.Spec.Tags)
+	require.Equal(t, []*v1alpha1.Tag{Tag2Updated}, added)
+	require.Equal(t, []string{"k1"}, aws.StringValueSlice(removed))
+
+	// Without managed tag ownership, only the AWS tags are left alone.
+	desired.ko.Annotations = nil
+	latest = &resource{ko: desired.ko.DeepCopy()}
+	latest.ko.Spec.Tags = observed
+	reportTagOwnership(desired, latest)
+	require.Len(t, latest.ko.Spec.Tags, 3)
+	require.Nil(t, latest.ko.Status.ForeignTags)
+	require.Nil(t, latest.ko.Status.ManagedTagKeys)
+}
@@ -0,0 +1,199 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	"github.com/aws/aws-sdk-go/aws"
+	svcsdk "github.com/aws/aws-sdk-go/service/dynamodb"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+// Steps of the field transitions reported in Status.Transitions.
+const (
+	TransitionStepDisabling = "Disabling"
+	TransitionStepEnabling  = "Enabling"
+)
+
+// Paths of the fields changed with transitions.
+const (
+	transitionPathStreamSpecification = "Spec.StreamSpecification"
+	transitionPathTimeToLive          = "Spec.TimeToLive"
+)
+
+var (
+	ErrTimeToLiveDisabling = fmt.Errorf("waiting for the time to live to be disabled")
+
+	// Disabling the time to live of a table takes up to one hour, and it
+	// can't be enabled again before.
+	requeueWaitTimeToLiveDisabled = ackrequeue.NeededAfter(
+		ErrTimeToLiveDisabling,
+		time.Minute,
+	)
+)
+
+// needsStreamTransition returns true if the view type of the enabled stream
+// of a table changes. DynamoDB requires disabling the stream first.
+func needsStreamTransition(desired, latest *resource) bool {
+	d, l := desired.ko.Spec.StreamSpecification, latest.ko.Spec.StreamSpecification
+	return d != nil && l != nil &&
+		aws.BoolValue(d.StreamEnabled) && aws.BoolValue(l.StreamEnabled) &&
+		!equalStrings(d.StreamViewType, l.StreamViewType)
+}
+
+// needsTimeToLiveTransition returns true if the attribute of the enabled time
+// to live of a table changes. DynamoDB requires disabling the time to live
+// first.
+func needsTimeToLiveTransition(desired, latest *resource) bool {
+	d, l := desired.ko.Spec.TimeToLive, latest.ko.Spec.TimeToLive
+	return d != nil && l != nil &&
+		aws.BoolValue(d.Enabled) && aws.BoolValue(l.Enabled) &&
+		!equalStrings(d.AttributeName, l.AttributeName)
+}
+
+// getTransition returns the transition of the field with the supplied path,
+// or nil if the field has none.
+func getTransition(ko *v1alpha1.Table, path string) *v1alpha1.FieldTransition {
+	for _, t := range ko.Status.Transitions {
+		if t != nil && aws.StringValue(t.Path) == path {
+			return t
+		}
+	}
+	return nil
+}
+
+// setTransitionStep records in ko the current step of the transition of the
+// field with the supplied path, and reports it in the Synced condition.
+func setTransitionStep(ko *v1alpha1.Table, path, step string) {
+	now := metav1.Now()
+	t := getTransition(ko, path)
+	if t == nil {
+		t = &v1alpha1.FieldTransition{Path: aws.String(path)}
+		ko.Status.Transitions = append(ko.Status.Transitions, t)
+	}
+	t.Step = aws.String(step)
+	t.StartTime = &now
+
+	msg := fmt.Sprintf("%s is changed in several steps, current step: %s", path, step)
+	setSyncedCondition(&resource{ko}, corev1.ConditionFalse, &msg, nil)
+}
+
+// pruneTransitions removes from the latest state of a table the transitions
+// of the fields whose desired value is reached.
+func pruneTransitions(desired, latest *resource) {
+	if len(latest.ko.Status.Transitions) == 0 {
+		return
+	}
+	delta := newResourceDelta(desired, latest)
+	var transitions []*v1alpha1.FieldTransition
+	for _, t := range latest.ko.Status.Transitions {
+		if t != nil && delta.DifferentAt(aws.StringValue(t.Path)) {
+			transitions = append(transitions, t)
+		}
+	}
+	latest.ko.Status.Transitions = transitions
+}
+
+// syncStreamSpecification updates the stream specification of a table.
+// Changing the view type of an enabled stream is made in two steps recorded
+// in ko.Status.Transitions: the stream is disabled, then enabled with the new
+// view type once the table is active again.
+func (rm *resourceManager) syncStreamSpecification(
+	ctx context.Context,
+	ko *v1alpha1.Table,
+	desired *resource,
+	latest *resource,
+	delta *ackcompare.Delta,
+) error {
+	if needsStreamTransition(desired, latest) {
+		disabled := desired.ko.DeepCopy()
+		disabled.Spec.StreamSpecification = &v1alpha1.StreamSpecification{
+			StreamEnabled: aws.Bool(false),
+		}
+		if err := rm.syncTable(ctx, &resource{disabled}, latest, delta); err != nil {
+			return err
+		}
+		setTransitionStep(ko, transitionPathStreamSpecification, TransitionStepDisabling)
+		return nil
+	}
+	if err := rm.syncTable(ctx, desired, latest, delta); err != nil {
+		return err
+	}
+	if getTransition(ko, transitionPathStreamSpecification) != nil {
+		setTransitionStep(ko, transitionPathStreamSpecification, TransitionStepEnabling)
+	}
+	return nil
+}
+
+// syncTimeToLive updates the time to live of a table. Changing the attribute
+// of an enabled time to live is made in two steps recorded in
+// ko.Status.Transitions: the time to live is disabled, then enabled with the
+// new attribute once DynamoDB reports it disabled. It returns false while the
+// time to live is being disabled.
+func (rm *resourceManager) syncTimeToLive(
+	ctx context.Context,
+	ko *v1alpha1.Table,
+	desired *resource,
+	latest *resource,
+) (bool, error) {
+	if needsTimeToLiveTransition(desired, latest) {
+		disabled := desired.ko.DeepCopy()
+		disabled.Spec.TimeToLive = nil
+		if err := rm.syncTTL(ctx, &resource{disabled}, latest); err != nil {
+			return false, err
+		}
+		setTransitionStep(ko, transitionPathTimeToLive, TransitionStepDisabling)
+		return false, nil
+	}
+
+	transition := getTransition(ko, transitionPathTimeToLive)
+	if transition != nil && desired.ko.Spec.TimeToLive != nil &&
+		aws.BoolValue(desired.ko.Spec.TimeToLive.Enabled) {
+		status, err := rm.getResourceTTLStatusWithContext(ctx, physicalTableName(desired.ko))
+		if err != nil {
+			return false, err
+		}
+		if status != svcsdk.TimeToLiveStatusDisabled {
+			return false, nil
+		}
+	}
+	if err := rm.syncTTL(ctx, desired, latest); err != nil {
+		if isTimeToLiveCooldown(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if transition != nil {
+		setTransitionStep(ko, transitionPathTimeToLive, TransitionStepEnabling)
+	}
+	return true, nil
+}
+
+// isTimeToLiveCooldown returns true if the supplied error reports that the
+// time to live of a table was modified too recently to be modified again.
+func isTimeToLiveCooldown(err error) bool {
+	awsErr, ok := ackerr.AWSError(err)
+	return ok && awsErr.Code() == "ValidationException" &&
+		strings.HasPrefix(awsErr.Message(), "Time to live has been modified multiple times")
+}
@@ -0,0 +1,116 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+func Test_needsStreamTransition(t *testing.T) {
+	stream := func(enabled bool, viewType string) *resource {
+		return &resource{ko: &v1alpha1.Table{Spec: v1alpha1.TableSpec{
+			StreamSpecification: &v1alpha1.StreamSpecification{
+				StreamEnabled:  aws.Bool(enabled),
+				StreamViewType: aws.String(viewType),
+			},
+		}}}
+	}
+	require.True(t, needsStreamTransition(stream(true, "NEW_IMAGE"), stream(true, "KEYS_ONLY")))
+	require.False(t, needsStreamTransition(stream(true, "NEW_IMAGE"), stream(true, "NEW_IMAGE")))
+	require.False(t, needsStreamTransition(stream(true, "NEW_IMAGE"), stream(false, "KEYS_ONLY")))
+	require.False(t, needsStreamTransition(stream(false, ""), stream(true, "KEYS_ONLY")))
+	require.False(t, needsStreamTransition(&resource{ko: &v1alpha1.Table{}}, stream(true, "KEYS_ONLY")))
+}
+
+func Test_needsTimeToLiveTransition(t *testing.T) {
+	ttl := func(enabled bool, attribute string) *resource {
+		return &resource{ko: &v1alpha1.Table{Spec: v1alpha1.TableSpec{
+			TimeToLive: &v1alpha1.TimeToLiveSpecification{
+				Enabled:       aws.Bool(enabled),
+				AttributeName: aws.String(attribute),
+			},
+		}}}
+	}
+	require.True(t, needsTimeToLiveTransition(ttl(true, "expiresAt"), ttl(true, "ttl")))
+	require.False(t, needsTimeToLiveTransition(ttl(true, "ttl"), ttl(true, "ttl")))
+	require.False(t, needsTimeToLiveTransition(ttl(true, "expiresAt"), ttl(false, "ttl")))
+	require.False(t, needsTimeToLiveTransition(ttl(false, "ttl"), ttl(true, "ttl")))
+}
+
+func Test_setTransitionStep(t *testing.T) {
+	ko := &v1alpha1.Table{}
+	setTransitionStep(ko, transitionPathStreamSpecification, TransitionStepDisabling)
+	setTransitionStep(ko, transitionPathTimeToLive, TransitionStepDisabling)
+	setTransitionStep(ko, transitionPathStreamSpecification, TransitionStepEnabling)
+
+	require.Len(t, ko.Status.Transitions, 2)
+	transition := getTransition(ko, transitionPathStreamSpecification)
+	require.NotNil(t, transition)
+	require.Equal(t, TransitionStepEnabling, *transition.Step)
+	require.NotNil(t, transition.StartTime)
+	require.Equal(t, TransitionStepDisabling, *getTransition(ko, transitionPathTimeToLive).Step)
+
+	synced := getSyncedCondition(&resource{ko})
+	require.NotNil(t, synced)
+	require.Equal(t, corev1.ConditionFalse, synced.Status)
+	require.Contains(t, *synced.Message, TransitionStepEnabling)
+}
+
+func Test_pruneTransitions(t *testing.T) {
+	latest := &resource{ko: &v1alpha1.Table{Spec: v1alpha1.TableSpec{
+		TableName: aws.String("t"),
+		StreamSpecification: &v1alpha1.StreamSpecification{
+			StreamEnabled:  aws.Bool(true),
+			StreamViewType: aws.String("NEW_IMAGE"),
+		},
+		TimeToLive: &v1alpha1.TimeToLiveSpecification{
+			Enabled:       aws.Bool(false),
+			AttributeName: aws.String("ttl"),
+		},
+	}}}
+	setTransitionStep(latest.ko, transitionPathStreamSpecification, TransitionStepEnabling)
+	setTransitionStep(latest.ko, transitionPathTimeToLive, TransitionStepDisabling)
+
+	desired := &resource{ko: latest.ko.DeepCopy()}
+	desired.ko.Spec.TimeToLive = &v1alpha1.TimeToLiveSpecification{
+		Enabled:       aws.Bool(true),
+		AttributeName: aws.String("expiresAt"),
+	}
+
+	pruneTransitions(desired, latest)
+	require.Len(t, latest.ko.Status.Transitions, 1)
+	require.Nil(t, getTransition(latest.ko, transitionPathStreamSpecification))
+	require.NotNil(t, getTransition(latest.ko, transitionPathTimeToLive))
+}
+
+func Test_isTimeToLiveCooldown(t *testing.T) {
+	require.True(t, isTimeToLiveCooldown(awserr.New(
+		"ValidationException",
+		"Time to live has been modified multiple times within a fixed interval",
+		nil,
+	)))
+	require.False(t, isTimeToLiveCooldown(awserr.New(
+		"ValidationException",
+		"TimeToLive is already disabled",
+		nil,
+	)))
+	require.False(t, isTimeToLiveCooldown(nil))
+}
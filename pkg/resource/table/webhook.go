@@ -0,0 +1,401 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	ackrtwebhook "github.com/aws-controllers-k8s/runtime/pkg/webhook"
+	"github.com/aws/aws-sdk-go/aws"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/dynamodb-controller/pkg/maintenance"
+)
+
+const (
+	// webhookTypeMutating is the type of the webhooks defaulting resources at
+	// admission time.
+	webhookTypeMutating = "mutating"
+	// webhookTypeValidating is the type of the webhooks rejecting invalid
+	// resources at admission time.
+	webhookTypeValidating = "validating"
+	// maxGlobalSecondaryIndexes is the maximum number of global secondary
+	// indexes of a table.
+	maxGlobalSecondaryIndexes = 20
+	// maxLocalSecondaryIndexes is the maximum number of local secondary
+	// indexes of a table.
+	maxLocalSecondaryIndexes = 5
+)
+
+// +kubebuilder:webhook:path=/mutate-dynamodb-services-k8s-aws-v1alpha1-table,mutating=true,failurePolicy=fail,sideEffects=None,groups=dynamodb.services.k8s.aws,resources=tables,verbs=create;update,versions=v1alpha1,name=mtable.dynamodb.services.k8s.aws,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-dynamodb-services-k8s-aws-v1alpha1-table,mutating=false,failurePolicy=fail,sideEffects=None,groups=dynamodb.services.k8s.aws,resources=tables,verbs=create;update,versions=v1alpha1,name=vtable.dynamodb.services.k8s.aws,admissionReviewVersions=v1
+
+func init() {
+	if err := ackrtwebhook.RegisterWebhook(ackrtwebhook.New(
+		v1alpha1.GroupVersion.Version,
+		GroupKind.Kind,
+		webhookTypeMutating,
+		func(mgr ctrlrt.Manager) error {
+			return ctrlrt.NewWebhookManagedBy(mgr).
+				For(&v1alpha1.Table{}).
+				WithDefaulter(&tableDefaulter{}).
+				Complete()
+		},
+	)); err != nil {
+		panic(err)
+	}
+	if err := ackrtwebhook.RegisterWebhook(ackrtwebhook.New(
+		v1alpha1.GroupVersion.Version,
+		GroupKind.Kind,
+		webhookTypeValidating,
+		func(mgr ctrlrt.Manager) error {
+			return ctrlrt.NewWebhookManagedBy(mgr).
+				For(&v1alpha1.Table{}).
+				WithValidator(&tableValidator{}).
+				Complete()
+		},
+	)); err != nil {
+		panic(err)
+	}
+}
+
+// tableDefaulter fills the defaults DynamoDB applies to tables into the Table
+// specs, so that the stored objects describe the tables explicitly.
+type tableDefaulter struct{}
+
+// Default implements admission.CustomDefaulter
+func (d *tableDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	ko, ok := obj.(*v1alpha1.Table)
+	if !ok {
+		return fmt.Errorf("expected a Table but got a %T", obj)
+	}
+	if ko.DeletionTimestamp != nil {
+		return nil
+	}
+	defaultTableSpec(&ko.Spec)
+	return nil
+}
+
+// tableValidator rejects Table specs that DynamoDB would refuse, or that the
+// controller is unable to reconcile, at admission time.
+type tableValidator struct{}
+
+// ValidateCreate implements admission.CustomValidator
+func (v *tableValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	ko, ok := obj.(*v1alpha1.Table)
+	if !ok {
+		return fmt.Errorf("expected a Table but got a %T", obj)
+	}
+	return toInvalidError(ko, validateTableSpec(&ko.Spec))
+}
+
+// ValidateUpdate implements admission.CustomValidator
+func (v *tableValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	oldKo, ok := oldObj.(*v1alpha1.Table)
+	if !ok {
+		return fmt.Errorf("expected a Table but got a %T", oldObj)
+	}
+	ko, ok := newObj.(*v1alpha1.Table)
+	if !ok {
+		return fmt.Errorf("expected a Table but got a %T", newObj)
+	}
+	// Never block updates that don't touch the spec (finalizers, annotations,
+	// status...) or that happen while the resource is being deleted, otherwise
+	// resources admitted before the webhook was enabled couldn't be managed
+	// anymore.
+	if ko.DeletionTimestamp != nil || reflect.DeepEqual(oldKo.Spec, ko.Spec) {
+		return nil
+	}
+	errs := validateTableSpec(&ko.Spec)
+	errs = append(errs, validateTableSpecUpdate(&oldKo.Spec, &ko.Spec)...)
+	return toInvalidError(ko, errs)
+}
+
+// ValidateDelete implements admission.CustomValidator
+func (v *tableValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+// toInvalidError returns an Invalid API error for the supplied field errors,
+// or nil if there are no errors.
+func toInvalidError(ko *v1alpha1.Table, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: GroupKind.Group, Kind: GroupKind.Kind},
+		ko.Name,
+		errs,
+	)
+}
+
+// validateTableSpec validates a Table spec.
+func validateTableSpec(spec *v1alpha1.TableSpec) field.ErrorList {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	attributes := map[string]bool{}
+	attributeTypes := map[string]string{}
+	for i, ad := range spec.AttributeDefinitions {
+		path := specPath.Child("attributeDefinitions").Index(i)
+		if ad == nil || emptyString(ad.AttributeName) {
+			errs = append(errs, field.Required(path.Child("attributeName"), ""))
+			continue
+		}
+		if attributes[*ad.AttributeName] {
+			if attributeTypes[*ad.AttributeName] != aws.StringValue(ad.AttributeType) {
+				errs = append(errs, field.Invalid(
+					path.Child("attributeType"),
+					aws.StringValue(ad.AttributeType),
+					"conflicts with the type "+attributeTypes[*ad.AttributeName]+
+						" declared for attribute "+*ad.AttributeName,
+				))
+			} else {
+				errs = append(errs, field.Duplicate(path.Child("attributeName"), *ad.AttributeName))
+			}
+			continue
+		}
+		attributes[*ad.AttributeName] = true
+		attributeTypes[*ad.AttributeName] = aws.StringValue(ad.AttributeType)
+	}
+
+	errs = append(errs, validateKeySchema(specPath.Child("keySchema"), spec.KeySchema, attributes)...)
+
+	gsisPath := specPath.Child("globalSecondaryIndexes")
+	if len(spec.GlobalSecondaryIndexes) > maxGlobalSecondaryIndexes {
+		errs = append(errs, field.TooMany(gsisPath, len(spec.GlobalSecondaryIndexes), maxGlobalSecondaryIndexes))
+	}
+	gsiNames := map[string]bool{}
+	for i, gsi := range spec.GlobalSecondaryIndexes {
+		path := gsisPath.Index(i)
+		if gsi == nil {
+			continue
+		}
+		if emptyString(gsi.IndexName) {
+			errs = append(errs, field.Required(path.Child("indexName"), ""))
+		} else if gsiNames[*gsi.IndexName] {
+			errs = append(errs, field.Duplicate(path.Child("indexName"), *gsi.IndexName))
+		} else {
+			gsiNames[*gsi.IndexName] = true
+		}
+		errs = append(errs, validateKeySchema(path.Child("keySchema"), gsi.KeySchema, attributes)...)
+		if isPayPerRequest(spec) && hasCapacity(gsi.ProvisionedThroughput) {
+			errs = append(errs, field.Forbidden(
+				path.Child("provisionedThroughput"),
+				"provisioned throughput cannot be set when billingMode is PAY_PER_REQUEST",
+			))
+		}
+	}
+
+	lsisPath := specPath.Child("localSecondaryIndexes")
+	if len(spec.LocalSecondaryIndexes) > maxLocalSecondaryIndexes {
+		errs = append(errs, field.TooMany(lsisPath, len(spec.LocalSecondaryIndexes), maxLocalSecondaryIndexes))
+	}
+	lsiNames := map[string]bool{}
+	for i, lsi := range spec.LocalSecondaryIndexes {
+		path := lsisPath.Index(i)
+		if lsi == nil {
+			continue
+		}
+		if emptyString(lsi.IndexName) {
+			errs = append(errs, field.Required(path.Child("indexName"), ""))
+		} else if lsiNames[*lsi.IndexName] || gsiNames[*lsi.IndexName] {
+			errs = append(errs, field.Duplicate(path.Child("indexName"), *lsi.IndexName))
+		} else {
+			lsiNames[*lsi.IndexName] = true
+		}
+		errs = append(errs, validateKeySchema(path.Child("keySchema"), lsi.KeySchema, attributes)...)
+	}
+
+	if isPayPerRequest(spec) && hasCapacity(spec.ProvisionedThroughput) {
+		errs = append(errs, field.Forbidden(
+			specPath.Child("provisionedThroughput"),
+			"provisioned throughput cannot be set when billingMode is PAY_PER_REQUEST",
+		))
+	}
+
+	if spec.StreamSpecification != nil &&
+		!aws.BoolValue(spec.StreamSpecification.StreamEnabled) &&
+		!emptyString(spec.StreamSpecification.StreamViewType) {
+		errs = append(errs, field.Forbidden(
+			specPath.Child("streamSpecification", "streamViewType"),
+			"streamViewType can only be set when streamEnabled is true",
+		))
+	}
+
+	if spec.SSESpecification != nil &&
+		spec.SSESpecification.KMSMasterKeyRef != nil &&
+		!emptyString(spec.SSESpecification.KMSMasterKeyID) {
+		errs = append(errs, field.Forbidden(
+			specPath.Child("sseSpecification", "kmsMasterKeyRef"),
+			"kmsMasterKeyRef cannot be set with kmsMasterKeyID",
+		))
+	}
+
+	errs = append(errs, validateCapacitySchedule(specPath.Child("capacitySchedule"), spec, gsiNames)...)
+
+	errs = append(errs, validateReplacementStrategy(specPath.Child("replacementStrategy"), spec.ReplacementStrategy)...)
+
+	if spec.MaintenanceWindow != nil {
+		if _, err := maintenance.NewWindow(spec.MaintenanceWindow); err != nil {
+			errs = append(errs, field.Invalid(
+				specPath.Child("maintenanceWindow"), spec.MaintenanceWindow, err.Error(),
+			))
+		}
+	}
+	return errs
+}
+
+// validateKeySchema validates a table or index key schema. Every key
+// attribute must be defined in the table attribute definitions.
+func validateKeySchema(
+	path *field.Path,
+	keySchema []*v1alpha1.KeySchemaElement,
+	attributes map[string]bool,
+) field.ErrorList {
+	var errs field.ErrorList
+	for i, ks := range keySchema {
+		if ks == nil || emptyString(ks.AttributeName) {
+			errs = append(errs, field.Required(path.Index(i).Child("attributeName"), ""))
+			continue
+		}
+		if !attributes[*ks.AttributeName] {
+			errs = append(errs, field.Invalid(
+				path.Index(i).Child("attributeName"),
+				*ks.AttributeName,
+				"attribute is not defined in spec.attributeDefinitions",
+			))
+		}
+	}
+	return errs
+}
+
+// validateCapacitySchedule validates the capacity schedule of a table whose
+// global secondary indexes have the supplied names.
+func validateCapacitySchedule(
+	path *field.Path,
+	spec *v1alpha1.TableSpec,
+	gsiNames map[string]bool,
+) field.ErrorList {
+	var errs field.ErrorList
+	if len(spec.CapacitySchedule) > 0 && isPayPerRequest(spec) {
+		return append(errs, field.Forbidden(
+			path, "capacity schedules cannot be set when billingMode is PAY_PER_REQUEST",
+		))
+	}
+	names := map[string]bool{}
+	for i, entry := range spec.CapacitySchedule {
+		entryPath := path.Index(i)
+		if entry == nil {
+			continue
+		}
+		if emptyString(entry.Name) {
+			errs = append(errs, field.Required(entryPath.Child("name"), ""))
+		} else if names[*entry.Name] {
+			errs = append(errs, field.Duplicate(entryPath.Child("name"), *entry.Name))
+		} else {
+			names[*entry.Name] = true
+		}
+		if _, _, err := parseCapacityScheduleEntry(entry); err != nil {
+			errs = append(errs, field.Invalid(entryPath, entry.Schedule, err.Error()))
+		}
+		for j, index := range entry.GlobalSecondaryIndexes {
+			if index == nil || emptyString(index.IndexName) {
+				errs = append(errs, field.Required(
+					entryPath.Child("globalSecondaryIndexes").Index(j).Child("indexName"), "",
+				))
+				continue
+			}
+			if !gsiNames[*index.IndexName] {
+				errs = append(errs, field.NotFound(
+					entryPath.Child("globalSecondaryIndexes").Index(j).Child("indexName"),
+					*index.IndexName,
+				))
+			}
+		}
+	}
+	return errs
+}
+
+// validateTableSpecUpdate validates the changes made to a Table spec. The key
+// schema and local secondary indexes of tables with a replacement strategy
+// can change.
+func validateTableSpecUpdate(oldSpec, spec *v1alpha1.TableSpec) field.ErrorList {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+	if !equalStrings(oldSpec.TableName, spec.TableName) {
+		errs = append(errs, field.Forbidden(specPath.Child("tableName"), "field is immutable"))
+	}
+	if spec.ReplacementStrategy != nil {
+		return errs
+	}
+	if len(oldSpec.KeySchema) != len(spec.KeySchema) ||
+		!equalKeySchemaArrays(oldSpec.KeySchema, spec.KeySchema) {
+		errs = append(errs, field.Forbidden(specPath.Child("keySchema"), "field is immutable"))
+	}
+	if len(oldSpec.LocalSecondaryIndexes) != len(spec.LocalSecondaryIndexes) ||
+		!equalLocalSecondaryIndexesArrays(oldSpec.LocalSecondaryIndexes, spec.LocalSecondaryIndexes) {
+		errs = append(errs, field.Forbidden(specPath.Child("localSecondaryIndexes"), "field is immutable"))
+	}
+	return errs
+}
+
+// validateReplacementStrategy validates the replacement strategy of a table.
+func validateReplacementStrategy(path *field.Path, strategy *v1alpha1.ReplacementStrategy) field.ErrorList {
+	var errs field.ErrorList
+	if strategy == nil {
+		return errs
+	}
+	if strategy.DataCopy != nil {
+		switch *strategy.DataCopy {
+		case DataCopyNone, DataCopyScanAndWrite:
+		default:
+			errs = append(errs, field.NotSupported(
+				path.Child("dataCopy"), *strategy.DataCopy,
+				[]string{DataCopyNone, DataCopyScanAndWrite},
+			))
+		}
+	}
+	if strategy.OldTablePolicy != nil {
+		switch *strategy.OldTablePolicy {
+		case OldTablePolicyRetain, OldTablePolicyDelete:
+		default:
+			errs = append(errs, field.NotSupported(
+				path.Child("oldTablePolicy"), *strategy.OldTablePolicy,
+				[]string{OldTablePolicyRetain, OldTablePolicyDelete},
+			))
+		}
+	}
+	return errs
+}
+
+// isPayPerRequest returns true if the supplied spec uses the PAY_PER_REQUEST
+// billing mode.
+func isPayPerRequest(spec *v1alpha1.TableSpec) bool {
+	return aws.StringValue(spec.BillingMode) == string(v1alpha1.BillingMode_PAY_PER_REQUEST)
+}
+
+// hasCapacity returns true if the supplied provisioned throughput has a non
+// zero read or write capacity.
+func hasCapacity(pt *v1alpha1.ProvisionedThroughput) bool {
+	return pt != nil &&
+		(aws.Int64Value(pt.ReadCapacityUnits) != 0 || aws.Int64Value(pt.WriteCapacityUnits) != 0)
+}
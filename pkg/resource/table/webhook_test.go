@@ -0,0 +1,217 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package table
+
+import (
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+func newValidTableSpec() v1alpha1.TableSpec {
+	return v1alpha1.TableSpec{
+		TableName: aws.String("t"),
+		AttributeDefinitions: []*v1alpha1.AttributeDefinition{
+			{AttributeName: aws.String("pk"), AttributeType: aws.String("S")},
+			{AttributeName: aws.String("sk"), AttributeType: aws.String("S")},
+		},
+		KeySchema: []*v1alpha1.KeySchemaElement{
+			{AttributeName: aws.String("pk"), KeyType: aws.String("HASH")},
+		},
+		BillingMode: aws.String(string(v1alpha1.BillingMode_PAY_PER_REQUEST)),
+	}
+}
+
+func Test_validateTableSpec(t *testing.T) {
+	tests := []struct {
+		name       string
+		mutate     func(spec *v1alpha1.TableSpec)
+		wantFields []string
+	}{
+		{
+			name:   "valid spec",
+			mutate: func(spec *v1alpha1.TableSpec) {},
+		},
+		{
+			name: "undefined key attribute",
+			mutate: func(spec *v1alpha1.TableSpec) {
+				spec.GlobalSecondaryIndexes = []*v1alpha1.GlobalSecondaryIndex{{
+					IndexName: aws.String("gsi"),
+					KeySchema: []*v1alpha1.KeySchemaElement{
+						{AttributeName: aws.String("missing"), KeyType: aws.String("HASH")},
+					},
+				}}
+			},
+			wantFields: []string{"spec.globalSecondaryIndexes[0].keySchema[0].attributeName"},
+		},
+		{
+			name: "conflicting attribute types",
+			mutate: func(spec *v1alpha1.TableSpec) {
+				spec.AttributeDefinitions = append(spec.AttributeDefinitions,
+					&v1alpha1.AttributeDefinition{AttributeName: aws.String("sk"), AttributeType: aws.String("N")},
+					&v1alpha1.AttributeDefinition{AttributeName: aws.String("pk"), AttributeType: aws.String("S")},
+				)
+			},
+			wantFields: []string{
+				"spec.attributeDefinitions[2].attributeType",
+				"spec.attributeDefinitions[3].attributeName",
+			},
+		},
+		{
+			name: "provisioned throughput with PAY_PER_REQUEST",
+			mutate: func(spec *v1alpha1.TableSpec) {
+				spec.ProvisionedThroughput = &v1alpha1.ProvisionedThroughput{
+					ReadCapacityUnits: aws.Int64(5),
+				}
+			},
+			wantFields: []string{"spec.provisionedThroughput"},
+		},
+		{
+			name: "stream view type without stream enabled",
+			mutate: func(spec *v1alpha1.TableSpec) {
+				spec.StreamSpecification = &v1alpha1.StreamSpecification{
+					StreamViewType: aws.String("NEW_IMAGE"),
+				}
+			},
+			wantFields: []string{"spec.streamSpecification.streamViewType"},
+		},
+		{
+			name: "too many local secondary indexes",
+			mutate: func(spec *v1alpha1.TableSpec) {
+				for i := 0; i <= maxLocalSecondaryIndexes; i++ {
+					spec.LocalSecondaryIndexes = append(spec.LocalSecondaryIndexes, nil)
+				}
+			},
+			wantFields: []string{"spec.localSecondaryIndexes"},
+		},
+		{
+			name: "capacity schedule with PAY_PER_REQUEST",
+			mutate: func(spec *v1alpha1.TableSpec) {
+				spec.CapacitySchedule = []*v1alpha1.CapacityScheduleEntry{{
+					Name:     aws.String("day"),
+					Schedule: aws.String("0 8 * * *"),
+				}}
+			},
+			wantFields: []string{"spec.capacitySchedule"},
+		},
+		{
+			name: "invalid capacity schedule entries",
+			mutate: func(spec *v1alpha1.TableSpec) {
+				spec.BillingMode = aws.String(string(v1alpha1.BillingMode_PROVISIONED))
+				spec.CapacitySchedule = []*v1alpha1.CapacityScheduleEntry{{
+					Name:     aws.String("day"),
+					Schedule: aws.String("0 8 * * *"),
+				}, {
+					Name:     aws.String("day"),
+					Schedule: aws.String("0 25 * * *"),
+					GlobalSecondaryIndexes: []*v1alpha1.IndexProvisionedThroughput{{
+						IndexName: aws.String("missing"),
+					}},
+				}}
+			},
+			wantFields: []string{
+				"spec.capacitySchedule[1].name",
+				"spec.capacitySchedule[1]",
+				"spec.capacitySchedule[1].globalSecondaryIndexes[0].indexName",
+			},
+		},
+		{
+			name: "KMS key reference with KMS key ID",
+			mutate: func(spec *v1alpha1.TableSpec) {
+				spec.SSESpecification = &v1alpha1.SSESpecification{
+					Enabled:        aws.Bool(true),
+					KMSMasterKeyID: aws.String("alias/table"),
+					KMSMasterKeyRef: &ackv1alpha1.AWSResourceReferenceWrapper{
+						From: &ackv1alpha1.AWSResourceReference{Name: aws.String("key")},
+					},
+				}
+			},
+			wantFields: []string{"spec.sseSpecification.kmsMasterKeyRef"},
+		},
+		{
+			name: "unsupported replacement strategy",
+			mutate: func(spec *v1alpha1.TableSpec) {
+				spec.ReplacementStrategy = &v1alpha1.ReplacementStrategy{
+					DataCopy:       aws.String("Export"),
+					OldTablePolicy: aws.String(OldTablePolicyDelete),
+				}
+			},
+			wantFields: []string{"spec.replacementStrategy.dataCopy"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := newValidTableSpec()
+			tt.mutate(&spec)
+			var fields []string
+			for _, err := range validateTableSpec(&spec) {
+				fields = append(fields, err.Field)
+			}
+			require.Equal(t, tt.wantFields, fields)
+		})
+	}
+}
+
+func Test_validateTableSpecUpdate(t *testing.T) {
+	oldSpec := newValidTableSpec()
+	spec := newValidTableSpec()
+	require.Empty(t, validateTableSpecUpdate(&oldSpec, &spec))
+
+	spec.KeySchema = append(spec.KeySchema, &v1alpha1.KeySchemaElement{
+		AttributeName: aws.String("sk"),
+		KeyType:       aws.String("RANGE"),
+	})
+	errs := validateTableSpecUpdate(&oldSpec, &spec)
+	require.Len(t, errs, 1)
+	require.Equal(t, "spec.keySchema", errs[0].Field)
+
+	// Tables with a replacement strategy are replaced instead.
+	spec.ReplacementStrategy = &v1alpha1.ReplacementStrategy{}
+	require.Empty(t, validateTableSpecUpdate(&oldSpec, &spec))
+
+	// Their table name doesn't change, the new table is reported in status.
+	spec.TableName = aws.String("table-v2")
+	errs = validateTableSpecUpdate(&oldSpec, &spec)
+	require.Len(t, errs, 1)
+	require.Equal(t, "spec.tableName", errs[0].Field)
+}
+
+func Test_defaultTableSpec(t *testing.T) {
+	spec := newValidTableSpec()
+	spec.BillingMode = nil
+	spec.GlobalSecondaryIndexes = []*v1alpha1.GlobalSecondaryIndex{{
+		IndexName: aws.String("gsi"),
+		KeySchema: []*v1alpha1.KeySchemaElement{
+			{AttributeName: aws.String("sk"), KeyType: aws.String("HASH")},
+		},
+	}}
+	defaultTableSpec(&spec)
+
+	require.Equal(t, string(v1alpha1.BillingMode_PROVISIONED), *spec.BillingMode)
+	require.Equal(t, string(v1alpha1.TableClass_STANDARD), *spec.TableClass)
+	require.False(t, *spec.TimeToLive.Enabled)
+	require.False(t, *spec.ContinuousBackups.PointInTimeRecoveryEnabled)
+	require.False(t, *spec.SSESpecification.Enabled)
+	require.False(t, *spec.StreamSpecification.StreamEnabled)
+	require.Equal(t, string(v1alpha1.ProjectionType_ALL), *spec.GlobalSecondaryIndexes[0].Projection.ProjectionType)
+
+	// Defaulting is idempotent
+	defaulted := spec.DeepCopy()
+	defaultTableSpec(&spec)
+	require.Equal(t, defaulted, &spec)
+}
@@ -0,0 +1,104 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package tags compares and converts the tags of the DynamoDB resources.
+package tags
+
+import (
+	"strings"
+
+	ackutil "github.com/aws-controllers-k8s/runtime/pkg/util"
+	svcsdk "github.com/aws/aws-sdk-go/service/dynamodb"
+
+	"github.com/aws-controllers-k8s/dynamodb-controller/apis/v1alpha1"
+)
+
+// AWSPrefix is the prefix of the tags assigned by AWS, which can't be added
+// or removed.
+const AWSPrefix = "aws:"
+
+// IsAWSTag returns true if the tag with the supplied key was assigned by AWS.
+func IsAWSTag(key string) bool {
+	return strings.HasPrefix(key, AWSPrefix)
+}
+
+// Equal returns true if two Tag arrays are equal regardless of the order of
+// their elements.
+func Equal(
+	a []*v1alpha1.Tag,
+	b []*v1alpha1.Tag,
+) bool {
+	added, removed := ComputeDelta(a, b)
+	return len(added) == 0 && len(removed) == 0
+}
+
+// FromSDK transforms a *svcsdk.Tag array to a *v1alpha1.Tag array.
+func FromSDK(svcTags []*svcsdk.Tag) []*v1alpha1.Tag {
+	tags := make([]*v1alpha1.Tag, len(svcTags))
+	for i := range svcTags {
+		tags[i] = &v1alpha1.Tag{
+			Key:   svcTags[i].Key,
+			Value: svcTags[i].Value,
+		}
+	}
+	return tags
+}
+
+// ToSDK transforms a *v1alpha1.Tag array to a *svcsdk.Tag array.
+func ToSDK(rTags []*v1alpha1.Tag) []*svcsdk.Tag {
+	tags := make([]*svcsdk.Tag, len(rTags))
+	for i := range rTags {
+		tags[i] = &svcsdk.Tag{
+			Key:   rTags[i].Key,
+			Value: rTags[i].Value,
+		}
+	}
+	return tags
+}
+
+// ComputeDelta compares two Tag arrays and returns the tags of a to add or
+// update in b, and the keys of the tags of b to remove.
+func ComputeDelta(
+	a []*v1alpha1.Tag,
+	b []*v1alpha1.Tag,
+) (added []*v1alpha1.Tag, removed []*string) {
+	var visitedIndexes []string
+mainLoop:
+	for _, aElement := range b {
+		visitedIndexes = append(visitedIndexes, *aElement.Key)
+		for _, bElement := range a {
+			if equalStrings(aElement.Key, bElement.Key) {
+				if !equalStrings(aElement.Value, bElement.Value) {
+					added = append(added, bElement)
+				}
+				continue mainLoop
+			}
+		}
+		removed = append(removed, aElement.Key)
+	}
+	for _, bElement := range a {
+		if !ackutil.InStrings(*bElement.Key, visitedIndexes) {
+			added = append(added, bElement)
+		}
+	}
+	return added, removed
+}
+
+// equalStrings returns true if two strings are equal, a nil string being
+// equal to an empty one.
+func equalStrings(a, b *string) bool {
+	if a == nil {
+		return b == nil || *b == ""
+	}
+	return (*a == "" && b == nil) || *a == *b
+}
@@ -0,0 +1,91 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package throttle
+
+import (
+	"fmt"
+	"time"
+
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	flagControlPlaneQPS    = "control-plane-qps"
+	flagControlPlaneBurst  = "control-plane-burst"
+	flagThrottleBaseDelay  = "throttle-requeue-base-delay"
+	flagThrottleMaxDelay   = "throttle-requeue-max-delay"
+	defaultBaseDelay       = 5 * time.Second
+	defaultMaxDelay        = 5 * time.Minute
+	defaultControlPlaneQPS = 0
+)
+
+// Config contains the throttling related configuration of the controller.
+type Config struct {
+	// ControlPlaneQPS is the sustained rate of DynamoDB control-plane calls
+	// the controller is allowed to make, across all resource managers. A
+	// value of zero disables client side rate limiting.
+	ControlPlaneQPS float64
+	// ControlPlaneBurst is the maximum number of control-plane calls that
+	// can be made at once before the rate limit kicks in.
+	ControlPlaneBurst int
+	// BaseDelay is the requeue delay used after the first throttled call.
+	BaseDelay time.Duration
+	// MaxDelay caps the requeue delay of consecutive throttled calls.
+	MaxDelay time.Duration
+}
+
+// BindFlags defines CLI/runtime configuration options
+func (cfg *Config) BindFlags() {
+	flag.Float64Var(
+		&cfg.ControlPlaneQPS, flagControlPlaneQPS,
+		defaultControlPlaneQPS,
+		"The maximum sustained rate (calls per second) of DynamoDB control-plane calls made by the controller."+
+			" A value of 0 disables client side rate limiting.",
+	)
+	flag.IntVar(
+		&cfg.ControlPlaneBurst, flagControlPlaneBurst,
+		10,
+		"The maximum number of DynamoDB control-plane calls that can be made at once when rate limiting is enabled.",
+	)
+	flag.DurationVar(
+		&cfg.BaseDelay, flagThrottleBaseDelay,
+		defaultBaseDelay,
+		"The requeue delay applied to a resource after its first throttled or quota-limited API call.",
+	)
+	flag.DurationVar(
+		&cfg.MaxDelay, flagThrottleMaxDelay,
+		defaultMaxDelay,
+		"The maximum requeue delay applied to a resource after consecutive throttled or quota-limited API calls.",
+	)
+}
+
+// Validate ensures the options are valid
+func (cfg *Config) Validate() error {
+	if cfg.ControlPlaneQPS < 0 {
+		return fmt.Errorf("invalid value for flag '%s': must not be negative", flagControlPlaneQPS)
+	}
+	if cfg.ControlPlaneQPS > 0 && cfg.ControlPlaneBurst < 1 {
+		return fmt.Errorf("invalid value for flag '%s': must be at least 1", flagControlPlaneBurst)
+	}
+	if cfg.BaseDelay <= 0 {
+		return fmt.Errorf("invalid value for flag '%s': must be positive", flagThrottleBaseDelay)
+	}
+	if cfg.MaxDelay < cfg.BaseDelay {
+		return fmt.Errorf(
+			"invalid value for flag '%s': must be greater than or equal to '%s'",
+			flagThrottleMaxDelay, flagThrottleBaseDelay,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package throttle contains the pieces shared by the resource managers to
+// cope with DynamoDB control-plane throttling: a controller-wide token bucket
+// limiting the rate of API calls, and an error classifier turning throttling
+// and quota errors into jittered exponential requeues.
+package throttle
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"golang.org/x/time/rate"
+)
+
+// quotaErrorCodes are the DynamoDB error codes returned when an account level
+// control-plane quota is reached. They are not reported as throttling errors
+// by aws-sdk-go.
+var quotaErrorCodes = []string{
+	// Returned when there are too many concurrent control-plane operations
+	// (CreateTable, UpdateTable, DeleteTable, GSI creations...) in the account.
+	"LimitExceededException",
+}
+
+var (
+	// limiter is the token bucket shared by every DynamoDB client built by the
+	// resource managers. A nil limiter disables client side rate limiting.
+	limiter *rate.Limiter
+	// backoff tracks consecutive throttled calls per resource.
+	backoff = newBackoffTracker(defaultBaseDelay, defaultMaxDelay)
+)
+
+// Setup configures the controller-wide rate limiter and the requeue backoff
+// from the supplied configuration. It must be called before the resource
+// managers are created.
+func Setup(cfg Config) {
+	if cfg.ControlPlaneQPS > 0 {
+		limiter = rate.NewLimiter(rate.Limit(cfg.ControlPlaneQPS), cfg.ControlPlaneBurst)
+	} else {
+		limiter = nil
+	}
+	backoff = newBackoffTracker(cfg.BaseDelay, cfg.MaxDelay)
+}
+
+// rateLimitHandlerName is the name of the request handler waiting for the
+// controller-wide token bucket.
+const rateLimitHandlerName = "dynamodb-controller.ControlPlaneRateLimit"
+
+// Instrument makes every request (and retry attempt) sent by the supplied
+// AWS client wait for a token from the controller-wide token bucket.
+func Instrument(c *client.Client) {
+	c.Handlers.Sign.PushFrontNamed(request.NamedHandler{
+		Name: rateLimitHandlerName,
+		Fn:   waitForToken,
+	})
+}
+
+// waitForToken blocks until the request is allowed by the rate limiter or its
+// context is cancelled.
+func waitForToken(r *request.Request) {
+	if limiter == nil {
+		return
+	}
+	if err := limiter.Wait(r.Context()); err != nil {
+		r.Error = err
+	}
+}
+
+// IsThrottlingError returns true if the supplied error is a DynamoDB
+// throttling or control-plane quota error.
+func IsThrottlingError(err error) bool {
+	awsErr, ok := ackerr.AWSError(err)
+	if !ok {
+		return false
+	}
+	if request.IsErrorThrottle(awsErr) {
+		return true
+	}
+	for _, code := range quotaErrorCodes {
+		if awsErr.Code() == code {
+			return true
+		}
+	}
+	return false
+}
+
+// RequeueOnThrottle returns a requeue error with a jittered exponential delay
+// if the supplied error is a throttling or quota error. The delay grows with
+// the number of consecutive throttled calls made for the resource identified
+// by key. Any other error is returned unchanged.
+func RequeueOnThrottle(key string, err error) error {
+	if err == nil || !IsThrottlingError(err) {
+		return err
+	}
+	return ackrequeue.NeededAfter(err, backoff.next(key))
+}
+
+// backoffTracker computes jittered exponential delays for consecutive
+// throttled calls made on behalf of the same resource.
+type backoffTracker struct {
+	sync.Mutex
+	baseDelay time.Duration
+	maxDelay  time.Duration
+	// attempts maps a resource key to its consecutive throttled calls
+	attempts map[string]*backoffAttempt
+}
+
+type backoffAttempt struct {
+	count int
+	last  time.Time
+}
+
+func newBackoffTracker(baseDelay, maxDelay time.Duration) *backoffTracker {
+	return &backoffTracker{
+		baseDelay: baseDelay,
+		maxDelay:  maxDelay,
+		attempts:  map[string]*backoffAttempt{},
+	}
+}
+
+// next records a throttled call for the supplied key and returns the delay
+// after which the resource should be requeued.
+func (b *backoffTracker) next(key string) time.Duration {
+	b.Lock()
+	defer b.Unlock()
+
+	now := time.Now()
+	attempt, found := b.attempts[key]
+	// Throttling streaks are considered over once the resource went twice
+	// the maximum delay without being throttled.
+	if !found || now.Sub(attempt.last) > 2*b.maxDelay {
+		attempt = &backoffAttempt{}
+		b.attempts[key] = attempt
+	}
+	attempt.last = now
+	attempt.count++
+	b.gc(now)
+
+	delay := b.baseDelay
+	for i := 1; i < attempt.count && delay < b.maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > b.maxDelay {
+		delay = b.maxDelay
+	}
+	// Use "equal jitter" so that resources throttled at the same time are
+	// not all requeued at the same time, while keeping a minimum delay.
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// gc forgets about the resources that are no longer being throttled.
+func (b *backoffTracker) gc(now time.Time) {
+	for key, attempt := range b.attempts {
+		if now.Sub(attempt.last) > 2*b.maxDelay {
+			delete(b.attempts, key)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package throttle
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_IsThrottlingError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil error", nil, false},
+		{"non AWS error", errors.New("boom"), false},
+		{"validation error", awserr.New("ValidationException", "invalid", nil), false},
+		{"throttling error", awserr.New("ThrottlingException", "slow down", nil), true},
+		{"quota error", awserr.New("LimitExceededException", "too many operations", nil), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, IsThrottlingError(tt.err))
+		})
+	}
+}
+
+func Test_RequeueOnThrottle(t *testing.T) {
+	Setup(Config{BaseDelay: time.Second, MaxDelay: 8 * time.Second})
+	defer Setup(Config{BaseDelay: defaultBaseDelay, MaxDelay: defaultMaxDelay})
+
+	err := errors.New("boom")
+	require.Equal(t, err, RequeueOnThrottle("table/ns/t", err))
+
+	throttled := awserr.New("ThrottlingException", "slow down", nil)
+	maxDelays := []time.Duration{
+		time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 8 * time.Second,
+	}
+	for _, maxDelay := range maxDelays {
+		var requeueErr *ackrequeue.RequeueNeededAfter
+		require.True(t, errors.As(RequeueOnThrottle("table/ns/t", throttled), &requeueErr))
+		require.GreaterOrEqual(t, requeueErr.Duration(), maxDelay/2)
+		require.LessOrEqual(t, requeueErr.Duration(), maxDelay)
+		require.ErrorIs(t, requeueErr, throttled)
+	}
+
+	// Other resources have their own backoff.
+	var requeueErr *ackrequeue.RequeueNeededAfter
+	require.True(t, errors.As(RequeueOnThrottle("table/ns/other", throttled), &requeueErr))
+	require.LessOrEqual(t, requeueErr.Duration(), time.Second)
+}
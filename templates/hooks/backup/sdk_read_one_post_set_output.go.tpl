@@ -1,3 +1,4 @@
+	backupMetrics.observe(ko)
 	if isBackupCreating(&resource{ko}) {
 		return &resource{ko}, requeueWaitWhileCreating
 	}
\ No newline at end of file
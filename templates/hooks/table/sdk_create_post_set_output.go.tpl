@@ -1,4 +1,11 @@
-    if desired.ko.Spec.TimeToLive != nil {
+	// Forget about any fields cached for a previous table with the same ARN.
+	additionalFields.invalidate(tableARN(&resource{ko}))
+	ko.Status.PhysicalTableName = ko.Spec.TableName
+	if isAutoAttributeDefinitions(desired) {
+		// Keep the declared attribute types, including the unused ones.
+		ko.Spec.AttributeDefinitions = desired.ko.Spec.AttributeDefinitions
+	}
+	if isTTLEnabled(desired.ko.Spec.TimeToLive) {
 		if err := rm.syncTTL(ctx, desired, &resource{ko}); err != nil {
 			return nil, err
 		}
@@ -1,6 +1,23 @@
+	if isObserveOnly(r) {
+		// Observe-only tables are left untouched in DynamoDB
+		additionalFields.invalidate(tableARN(r))
+		forgetTableMetrics(r.ko)
+		return nil, nil
+	}
 	if isTableDeleting(r) {
 		return nil, requeueWaitWhileDeleting
 	}
+	if latest, err := holdDeletionForDependents(ctx, r); latest != nil || err != nil {
+		return latest, err
+	}
+	if latest, err := rm.deleteReplicas(ctx, r); latest != nil || err != nil {
+		return latest, err
+	}
 	if isTableUpdating(r) {
 		return nil, requeueWaitWhileUpdating
-	}
\ No newline at end of file
+	}
+	if err := rm.deleteReplacementTable(ctx, r); err != nil {
+		return nil, err
+	}
+	additionalFields.invalidate(tableARN(r))
+	forgetTableMetrics(r.ko)
\ No newline at end of file
@@ -1,3 +1,7 @@
+	// The table read is the physical table of the resource, whose name only
+	// differs from Spec.TableName once the table is replaced.
+	ko.Status.PhysicalTableName = ko.Spec.TableName
+	ko.Spec.TableName = r.ko.Spec.TableName
 	if resp.Table.GlobalSecondaryIndexes != nil {
 		f := []*svcapitypes.GlobalSecondaryIndexDescription{}
 		for _, fIter := range resp.Table.GlobalSecondaryIndexes {
@@ -20,6 +24,7 @@
 			if fIter.Backfilling != nil {
 				fElem.Backfilling = fIter.Backfilling
 			}
+			fElem.ProvisionedThroughput = newProvisionedThroughputDescription(fIter.ProvisionedThroughput)
 			f = append(f, fElem)
 		}
 		ko.Status.GlobalSecondaryIndexesDescriptions = f
@@ -53,6 +58,29 @@
 	} else {
 		ko.Spec.BillingMode = aws.String("PROVISIONED")
 	}
+	ko.Status.BillingModeSummary = newBillingModeSummary(resp.Table.BillingModeSummary)
+	ko.Status.ProvisionedThroughputDescription = newProvisionedThroughputDescription(resp.Table.ProvisionedThroughput)
+	if !isTableDeleting(&resource{ko}) {
+		observeTableMetrics(ko)
+	}
+	// The update plan and the next maintenance window are only reported
+	// while there are changes to make, they are set by customUpdateTable.
+	ko.Status.Plan = nil
+	ko.Status.NextMaintenanceWindow = nil
+	// DynamoDB can access the key again.
+	if !isEncryptionKeyInaccessible(&resource{ko}) {
+		removeConditionOfType(&resource{ko}, ConditionTypeEncryptionKeyInaccessible)
+	}
+	// Tables whose key is inaccessible or that were archived can still be
+	// deleted.
+	if !r.IsBeingDeleted() {
+		if isEncryptionKeyInaccessible(&resource{ko}) {
+			return &resource{ko}, rm.reportInaccessibleEncryptionKey(ctx, rm.newKMSAPI(), ko, resp.Table.SSEDescription)
+		}
+		if isTableArchived(&resource{ko}) {
+			return &resource{ko}, newTableArchivedError(ko)
+		}
+	}
 	if isTableCreating(&resource{ko}) {
 		return &resource{ko}, requeueWaitWhileCreating
 	}
@@ -61,4 +89,8 @@
 	}
 	if err := rm.setResourceAdditionalFields(ctx, ko); err != nil {
 		return nil, err
-	}
\ No newline at end of file
+	}
+	reportTagOwnership(r, &resource{ko})
+	rm.reportDrift(r, &resource{ko})
+	pruneTransitions(r, &resource{ko})
+	reportCapacitySchedule(r, &resource{ko})
\ No newline at end of file